@@ -0,0 +1,158 @@
+package geoserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBulkSkipped is the result of the bulk operation which isn't run cause a previous operation failed and BulkOptions.StopOnError is set
+var ErrBulkSkipped = errors.New("operation is skipped after a previous failure")
+
+// BulkOperation is a single item of the bulk batch, Name identifies the item in the results (e.g. the layer name)
+type BulkOperation struct {
+	Name string
+	Run  func() error
+}
+
+// BulkOptions configures the bulk executor,
+// Workers is the number of operations run concurrently (1 if not set),
+// RateLimit is the maximum number of operations started per second (0 means no limit),
+// StopOnError skips the operations not started yet after the first failure
+type BulkOptions struct {
+	Workers     int
+	RateLimit   float64
+	StopOnError bool
+}
+
+// BulkResult is the result of the bulk operation
+type BulkResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// BulkError aggregates the failed operations of the bulk batch
+type BulkError struct {
+	Total  int
+	Failed []BulkResult
+}
+
+func (e BulkError) Error() string {
+	messages := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		messages = append(messages, fmt.Sprintf("%s: %v", r.Name, r.Err))
+	}
+	return fmt.Sprintf("%d of %d operations failed: %s", len(e.Failed), e.Total, strings.Join(messages, "; "))
+}
+
+// RunBulk runs the operations using the pool of workers respecting the rate limit,
+// results are returned in the order of the operations, err is BulkError if any operation failed else nil
+func RunBulk(operations []BulkOperation, options BulkOptions) (results []BulkResult, err error) {
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	var ticker *time.Ticker
+	if options.RateLimit > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / options.RateLimit))
+		defer ticker.Stop()
+	}
+
+	results = make([]BulkResult, len(operations))
+	jobs := make(chan int)
+	var failed sync.Once
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runBulkOperation(operations[i], ticker, stop)
+				if results[i].Err != nil && options.StopOnError {
+					failed.Do(func() { close(stop) })
+				}
+			}
+		}()
+	}
+	for i := range operations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	bulkErr := BulkError{Total: len(operations)}
+	for _, r := range results {
+		if r.Err != nil {
+			bulkErr.Failed = append(bulkErr.Failed, r)
+		}
+	}
+	if len(bulkErr.Failed) != 0 {
+		return results, bulkErr
+	}
+	return results, nil
+}
+
+// runBulkOperation waits for the rate limiter and runs the operation unless the batch is stopped
+func runBulkOperation(operation BulkOperation, ticker *time.Ticker, stop chan struct{}) BulkResult {
+	result := BulkResult{Name: operation.Name}
+	if ticker != nil {
+		select {
+		case <-ticker.C:
+		case <-stop:
+		}
+	}
+	select {
+	case <-stop:
+		result.Err = ErrBulkSkipped
+		return result
+	default:
+	}
+	started := time.Now()
+	result.Err = operation.Run()
+	result.Duration = time.Since(started)
+	return result
+}
+
+// ForEachLayer runs fn for every layer of the workspace (all layers if workspaceName is "") using the bulk executor,
+// e.g. to reassign styles with SetLayerDefaultStyle
+func (g *GeoServer) ForEachLayer(workspaceName string, fn func(layerName string) error, options BulkOptions) (results []BulkResult, err error) {
+	layers, err := g.GetLayers(workspaceName)
+	if err != nil {
+		return nil, err
+	}
+	return RunBulk(bulkOperations(layers, fn), options)
+}
+
+// ForEachDatastore runs fn for every datastore of the workspace using the bulk executor,
+// e.g. to enable all stores with UpdateDatastore
+func (g *GeoServer) ForEachDatastore(workspaceName string, fn func(datastoreName string) error, options BulkOptions) (results []BulkResult, err error) {
+	datastores, err := g.GetDatastores(workspaceName)
+	if err != nil {
+		return nil, err
+	}
+	return RunBulk(bulkOperations(datastores, fn), options)
+}
+
+// ForEachFeatureType runs fn for every feature type of the datastore using the bulk executor,
+// e.g. to update titles with UpdateFeatureType
+func (g *GeoServer) ForEachFeatureType(workspaceName string, datastoreName string, fn func(featureTypeName string) error, options BulkOptions) (results []BulkResult, err error) {
+	featureTypes, err := g.GetFeatureTypes(workspaceName, datastoreName)
+	if err != nil {
+		return nil, err
+	}
+	return RunBulk(bulkOperations(featureTypes, fn), options)
+}
+
+// bulkOperations builds the operations calling fn with the resource name for every resource
+func bulkOperations(resources []*Resource, fn func(name string) error) []BulkOperation {
+	operations := make([]BulkOperation, 0, len(resources))
+	for _, r := range resources {
+		name := r.Name
+		operations = append(operations, BulkOperation{Name: name, Run: func() error { return fn(name) }})
+	}
+	return operations
+}
@@ -0,0 +1,56 @@
+package geoserver
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBulk(t *testing.T) {
+	var running, maxRunning int32
+	operations := make([]BulkOperation, 0, 20)
+	for i := 0; i < 20; i++ {
+		i := i
+		operations = append(operations, BulkOperation{Name: fmt.Sprintf("layer%d", i), Run: func() error {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if i%7 == 0 {
+				return errors.New("failed")
+			}
+			return nil
+		}})
+	}
+
+	results, err := RunBulk(operations, BulkOptions{Workers: 4})
+	assert.Len(t, results, 20)
+	assert.Equal(t, "layer5", results[5].Name)
+	assert.Nil(t, results[5].Err)
+	assert.True(t, atomic.LoadInt32(&maxRunning) <= 4)
+	bulkErr, ok := err.(BulkError)
+	assert.True(t, ok)
+	assert.Equal(t, 20, bulkErr.Total)
+	assert.Len(t, bulkErr.Failed, 3)
+	assert.Equal(t, "layer0", bulkErr.Failed[0].Name)
+
+	results, err = RunBulk(operations[:3], BulkOptions{Workers: 1, StopOnError: true})
+	assert.NotNil(t, err)
+	assert.EqualError(t, results[0].Err, "failed")
+	assert.Equal(t, ErrBulkSkipped, results[2].Err)
+
+	started := time.Now()
+	results, err = RunBulk(operations[1:5], BulkOptions{Workers: 4, RateLimit: 100})
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	assert.True(t, time.Since(started) >= 40*time.Millisecond)
+}
@@ -0,0 +1,225 @@
+package geoserver
+
+import (
+	"container/list"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReadCache caches the successful GET responses of the catalog resources (the workspaces, the stores, the resources,
+// the layers, the layergroups and the styles) per request url, the other reads (e.g. the imports, the settings, the logging) aren't cached,
+// the entries expire after ttl, the least recently used entries are evicted when the cache holds maxEntries,
+// the writes (POST, PUT, DELETE) made by the client invalidate the cached entries of the written resource,
+// its parents (e.g. the listings) and its children, the writes of the workspaces, the stores, the resources and the layers
+// also invalidate the layers and the layergroups listings, the cache can be shared between the clients,
+// the responses are cached per user
+type ReadCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	generation uint64
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	path    string
+	data    []byte
+	expires time.Time
+}
+
+// NewReadCache returns the cache, maxEntries 0 means the number of the entries isn't limited
+func NewReadCache(ttl time.Duration, maxEntries int) *ReadCache {
+	return &ReadCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// SetCache enables caching of the catalog reads with the cache, nil disables caching
+func (g *GeoServer) SetCache(cache *ReadCache) {
+	g.cache = cache
+}
+
+// Cache returns the cache of the client, nil if caching isn't enabled
+func (g *GeoServer) Cache() *ReadCache {
+	return g.cache
+}
+
+// FlushCache removes all cached entries
+func (g *GeoServer) FlushCache() {
+	if g.cache != nil {
+		g.cache.Flush()
+	}
+}
+
+// cacheKey returns the key of the request response
+func cacheKey(request *http.Request) string {
+	username, _, _ := request.BasicAuth()
+	return username + " " + request.Header.Get(acceptHeader) + " " + request.URL.String()
+}
+
+// cachePath returns the normalized path of the requested resource used for the invalidation,
+// the format extension is removed and the layer alias /rest/layers/ws:layer is replaced by /rest/workspaces/ws/layers/layer
+func cachePath(requestPath string) string {
+	requestPath = strings.TrimSuffix(requestPath, "/")
+	if ext := path.Ext(requestPath); ext == ".json" || ext == ".xml" || ext == ".sld" {
+		requestPath = strings.TrimSuffix(requestPath, ext)
+	}
+	dir, name := path.Split(requestPath)
+	if strings.HasSuffix(dir, "/rest/layers/") && !strings.HasSuffix(dir, "/gwc/rest/layers/") {
+		if workspaceName, layerName := splitQualifiedName(name); workspaceName != "" {
+			requestPath = strings.TrimSuffix(dir, "layers/") + path.Join("workspaces", workspaceName, "layers", layerName)
+		}
+	}
+	return requestPath
+}
+
+// catalogCollections are the workspace collections which reads are cached besides the layer collections
+var catalogCollections = map[string]bool{
+	"layergroups": true,
+	"styles":      true,
+}
+
+// cacheable returns true if the reads of the request path are cached, see ReadCache
+func cacheable(requestPath string) bool {
+	requestPath = cachePath(requestPath)
+	i := strings.Index(requestPath, "/rest/")
+	if i < 0 || strings.HasSuffix(requestPath[:i], "/gwc") {
+		return false
+	}
+	segments := strings.Split(requestPath[i+len("/rest/"):], "/")
+	switch segments[0] {
+	case "namespaces", "layers", "layergroups", "styles":
+		return true
+	case "workspaces":
+		return len(segments) < 3 || layerCollections[segments[2]] || catalogCollections[segments[2]]
+	}
+	return false
+}
+
+// layerCollections are the workspace collections which writes create or delete the layers
+var layerCollections = map[string]bool{
+	"datastores":     true,
+	"coveragestores": true,
+	"wmsstores":      true,
+	"wmtsstores":     true,
+	"featuretypes":   true,
+	"coverages":      true,
+	"wmslayers":      true,
+	"layers":         true,
+}
+
+// dependentPaths returns the listings affected by the write of the normalized resource path besides its parents and children,
+// e.g. publishing a feature type creates the layer and deleting a store with recurse deletes its layers and removes them from the layergroups
+func dependentPaths(resourcePath string) []string {
+	i := strings.Index(resourcePath, "/rest/workspaces/")
+	if i < 0 {
+		return nil
+	}
+	prefix := resourcePath[:i]
+	segments := strings.Split(resourcePath[i+len("/rest/workspaces/"):], "/")
+	if len(segments) > 1 && !layerCollections[segments[1]] {
+		return nil
+	}
+	workspacePath := prefix + "/rest/workspaces/" + segments[0]
+	return []string{
+		workspacePath + "/layers",
+		workspacePath + "/layergroups",
+		prefix + "/rest/layers",
+		prefix + "/rest/layergroups",
+		prefix + "/gwc/rest/layers",
+	}
+}
+
+// currentGeneration returns the number of the invalidations made, it's taken before the request is sent
+// and passed to put to skip caching of the response read concurrently with the invalidation
+func (c *ReadCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// get returns the cached response data
+func (c *ReadCache) get(key string) (data []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if c.now().After(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.data, true
+}
+
+// put caches the response data unless the cache was invalidated after the generation was taken
+func (c *ReadCache) put(key string, requestPath string, data []byte, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	entry := &cacheEntry{key: key, path: cachePath(requestPath), data: data, expires: c.now().Add(c.ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *ReadCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// Invalidate removes the cached entries of the resource path (e.g. /geoserver/rest/workspaces/ws/layers/roads),
+// its parents, its children and the dependent layers and layergroups listings
+func (c *ReadCache) Invalidate(resourcePath string) {
+	resourcePath = cachePath(resourcePath)
+	paths := append([]string{resourcePath}, dependentPaths(resourcePath)...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, element := range c.entries {
+		entryPath := element.Value.(*cacheEntry).path
+		for _, p := range paths {
+			if entryPath == p || strings.HasPrefix(entryPath, p+"/") || strings.HasPrefix(p, entryPath+"/") {
+				c.remove(element)
+				break
+			}
+		}
+	}
+}
+
+// Flush removes all cached entries
+func (c *ReadCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Len returns the number of the cached entries
+func (c *ReadCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,186 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCache(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/rest/workspaces":
+			w.Write([]byte(`{"workspaces":{"workspace":[{"name":"ws"}]}}`))
+		case "/rest/workspaces/ws", "/rest/workspaces/other":
+			w.Write([]byte(`{"workspace":{"name":"ws"}}`))
+		case "/rest/workspaces/ws/layers/roads":
+			w.Write([]byte(`{"layer":{"name":"roads"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	cache := NewReadCache(time.Minute, 3)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	catalog.SetCache(cache)
+
+	for i := 0; i < 2; i++ {
+		_, err := catalog.GetWorkspaces()
+		assert.Nil(t, err)
+		_, err = catalog.GetLayer("ws", "roads")
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, requests["/rest/workspaces"])
+	assert.Equal(t, 1, requests["/rest/workspaces/ws/layers/roads"])
+
+	// not found responses aren't cached
+	_, err := catalog.GetLayer("ws", "missing")
+	assert.NotNil(t, err)
+	_, err = catalog.GetLayer("ws", "missing")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, requests["/rest/workspaces/ws/layers/missing"])
+
+	// the update of the layer invalidates the layer and the parent listing
+	_, err = catalog.UpdateLayer("", "ws:roads", Layer{Queryable: true})
+	assert.Nil(t, err)
+	_, _ = catalog.GetWorkspaces()
+	_, _ = catalog.GetLayer("ws", "roads")
+	assert.Equal(t, 2, requests["/rest/workspaces"])
+	assert.Equal(t, 2, requests["/rest/workspaces/ws/layers/roads"])
+
+	// the deletion of the workspace invalidates its children
+	_, err = catalog.DeleteWorkspace("ws", true)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	// the least recently used entry is evicted
+	_, _ = catalog.GetWorkspaces()
+	_, _ = catalog.GetWorkspace("ws")
+	_, _ = catalog.GetLayer("ws", "roads")
+	_, _ = catalog.GetWorkspaces()
+	_, _ = catalog.GetWorkspace("other")
+	assert.Equal(t, 3, cache.Len())
+	_, _ = catalog.GetWorkspaces()
+	_, _ = catalog.GetWorkspace("ws")
+	assert.Equal(t, 3, requests["/rest/workspaces"])
+	assert.Equal(t, 2, requests["/rest/workspaces/ws"])
+
+	// the entries expire after ttl
+	now = now.Add(2 * time.Minute)
+	_, _ = catalog.GetWorkspaces()
+	assert.Equal(t, 4, requests["/rest/workspaces"])
+
+	catalog.FlushCache()
+	assert.Equal(t, 0, cache.Len())
+	_, _ = catalog.GetWorkspaces()
+	assert.Equal(t, 5, requests["/rest/workspaces"])
+}
+
+func TestReadCacheDependentListings(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/rest/workspaces/ws/layers", "/rest/layers":
+			w.Write([]byte(`{"layers":""}`))
+		case "/rest/workspaces/ws/layergroups":
+			w.Write([]byte(`{"layerGroups":""}`))
+		case "/rest/workspaces/ws/layers/roads":
+			w.Write([]byte(`{"layer":{"name":"roads"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	cache := NewReadCache(time.Minute, 0)
+	catalog.SetCache(cache)
+	read := func() {
+		_, _ = catalog.GetLayers("ws")
+		_, _ = catalog.GetLayers("")
+		_, _ = catalog.GetLayerGroups("ws")
+		_, _ = catalog.GetLayer("ws", "roads")
+	}
+	read()
+	read()
+	assert.Equal(t, 1, requests["/rest/workspaces/ws/layers"])
+
+	// publishing the feature type creates the layer
+	_, err := catalog.CreateFeatureType("ws", "pg", &FeatureType{Name: "rivers"})
+	assert.Nil(t, err)
+	read()
+	assert.Equal(t, 2, requests["/rest/workspaces/ws/layers"])
+	assert.Equal(t, 2, requests["/rest/layers"])
+	assert.Equal(t, 2, requests["/rest/workspaces/ws/layergroups"])
+	assert.Equal(t, 2, requests["/rest/workspaces/ws/layers/roads"])
+
+	// deleting the store with recurse deletes its layers
+	_, _ = catalog.DeleteDatastore("ws", "pg", true)
+	read()
+	assert.Equal(t, 3, requests["/rest/workspaces/ws/layers"])
+	assert.Equal(t, 3, requests["/rest/workspaces/ws/layers/roads"])
+
+	// the styles don't affect the layers listings
+	_, _ = catalog.DeleteStyle("ws", "line", true)
+	read()
+	assert.Equal(t, 3, requests["/rest/workspaces/ws/layers"])
+
+	// the responses are cached per user
+	other := GetCatalog(server.URL+"/", "viewer", "viewer")
+	other.SetCache(cache)
+	_, _ = other.GetLayers("ws")
+	assert.Equal(t, 4, requests["/rest/workspaces/ws/layers"])
+}
+
+func TestCachePath(t *testing.T) {
+	assert.Equal(t, "/geoserver/rest/workspaces/ws/layers/roads", cachePath("/geoserver/rest/layers/ws:roads.json"))
+	assert.Equal(t, "/rest/layers/roads", cachePath("/rest/layers/roads"))
+	assert.Equal(t, "/rest/workspaces/ws/styles/line", cachePath("/rest/workspaces/ws/styles/line.sld"))
+	assert.Equal(t, "/gwc/rest/layers/ws:roads", cachePath("/gwc/rest/layers/ws:roads.json"))
+}
+
+func TestCacheable(t *testing.T) {
+	for requestPath, expected := range map[string]bool{
+		"/geoserver/rest/workspaces":                                     true,
+		"/geoserver/rest/workspaces/ws.json":                             true,
+		"/geoserver/rest/workspaces/ws/datastores/pg/featuretypes/roads": true,
+		"/geoserver/rest/workspaces/ws/styles/line.sld":                  true,
+		"/geoserver/rest/layers/ws:roads":                                true,
+		"/geoserver/rest/styles":                                         true,
+		"/geoserver/rest/workspaces/ws/settings":                         false,
+		"/geoserver/rest/imports/1":                                      false,
+		"/geoserver/rest/imports/1/tasks/0/progress":                     false,
+		"/geoserver/rest/logging":                                        false,
+		"/geoserver/rest/settings":                                       false,
+		"/geoserver/rest/about/version":                                  false,
+		"/geoserver/gwc/rest/layers/ws:roads":                            false,
+	} {
+		assert.Equal(t, expected, cacheable(requestPath), requestPath)
+	}
+}
+
+func TestReadCacheConcurrentInvalidation(t *testing.T) {
+	cache := NewReadCache(time.Minute, 0)
+	generation := cache.currentGeneration()
+	// the write invalidates the cache while the read is in flight
+	cache.Invalidate("/rest/workspaces/ws/layers/roads")
+	cache.put("key", "/rest/workspaces/ws/layers/roads", []byte("stale"), generation)
+	assert.Equal(t, 0, cache.Len())
+	cache.put("key", "/rest/workspaces/ws/layers/roads", []byte("fresh"), cache.currentGeneration())
+	assert.Equal(t, 1, cache.Len())
+}
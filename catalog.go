@@ -17,6 +17,15 @@ type Catalog interface {
 	LayerGroupService
 	CoverageStoresService
 	FeatureTypeService
+	WMSStoreService
+	WMSLayerService
+	WMTSStoreService
+	WMTSLayerService
+	SettingsService
+	OWSServiceSettingsService
+	ResourceService
+	TemplateService
+	ImporterService
 	UtilsInterface
 }
 
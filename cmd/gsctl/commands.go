@@ -0,0 +1,663 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/archer-v/geoserver"
+)
+
+// commands maps the resource to its actions
+var commands = map[string]map[string]command{
+	"workspaces": {
+		"list":   {"", listWorkspaces},
+		"get":    {"<workspace>", getWorkspace},
+		"create": {"<workspace>", createWorkspace},
+		"delete": {"[-recurse] <workspace>", deleteWorkspace},
+	},
+	"datastores": {
+		"list":   {"", listDatastores},
+		"get":    {"<datastore>", getDatastore},
+		"delete": {"[-recurse] <datastore>", deleteDatastore},
+	},
+	"featuretypes": {
+		"list":   {"<datastore>", listFeatureTypes},
+		"get":    {"<datastore> <featuretype>", getFeatureType},
+		"delete": {"[-recurse] <datastore> <featuretype>", deleteFeatureType},
+	},
+	"coverages": {
+		"list":   {"", listCoverages},
+		"get":    {"<coverage>", getCoverage},
+		"delete": {"[-recurse] <coverage>", deleteCoverage},
+	},
+	"layers": {
+		"list":      {"", listLayers},
+		"get":       {"<layer>", getLayer},
+		"delete":    {"[-recurse] <layer>", deleteLayer},
+		"set-style": {"<layer> <style>", setLayerStyle},
+	},
+	"layergroups": {
+		"list":   {"", listLayerGroups},
+		"get":    {"<layergroup>", getLayerGroup},
+		"delete": {"<layergroup>", deleteLayerGroup},
+	},
+	"styles": {
+		"list":   {"", listStyles},
+		"get":    {"<style>", getStyle},
+		"upload": {"[-overwrite] <style> <file>", uploadStyle},
+		"delete": {"[-purge] <style>", deleteStyle},
+	},
+	"acl": {
+		"list":   {"", listAclRules},
+		"add":    {"<workspace.layer.operation> <role,...>", addAclRule},
+		"update": {"<workspace.layer.operation> <role,...>", updateAclRule},
+		"delete": {"<workspace.layer.operation>", deleteAclRule},
+	},
+	"users": {
+		"list":   {"[-service name]", listUsers},
+		"create": {"[-service name] [-password-file file|-] [-password-env name] <user>", createUser},
+		"delete": {"[-service name] <user>", deleteUser},
+		"roles":  {"<user>", listUserRoles},
+	},
+	"roles": {
+		"list":     {"", listRoles},
+		"create":   {"<role>", createRole},
+		"delete":   {"<role>", deleteRole},
+		"assign":   {"<role> <user>", assignRole},
+		"unassign": {"<role> <user>", unassignRole},
+	},
+	"gwc": {
+		"seed":  {"[-type seed|reseed|truncate] [-gridset name] [-format mime] [-zoom-start n] [-zoom-stop n] [-threads n] <layer>", seedLayer},
+		"tasks": {"<layer>", listSeedTasks},
+	},
+}
+
+func resourcesTable(resources []*geoserver.Resource) table {
+	t := table{headers: []string{"NAME"}, data: resources}
+	for _, r := range resources {
+		t.rows = append(t.rows, []string{r.Name})
+	}
+	return t
+}
+
+func namesTable(header string, names []string) table {
+	t := table{headers: []string{header}, data: names}
+	for _, name := range names {
+		t.rows = append(t.rows, []string{name})
+	}
+	return t
+}
+
+func noFlags(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func listWorkspaces(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	workspaces, err := c.gs.GetWorkspaces()
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(workspaces))
+}
+
+func getWorkspace(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	workspace, err := c.gs.GetWorkspace(args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(workspace)
+}
+
+func createWorkspace(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("create"), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.CreateWorkspace(args[0]); err != nil {
+		return err
+	}
+	return c.out.printDone("workspace %s created", args[0])
+}
+
+func deleteWorkspace(c *cli, args []string) error {
+	flags := noFlags("delete")
+	recurse := flags.Bool("recurse", false, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteWorkspace(args[0], *recurse); err != nil {
+		return err
+	}
+	return c.out.printDone("workspace %s deleted", args[0])
+}
+
+func listDatastores(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	if err := c.requireWorkspace(); err != nil {
+		return err
+	}
+	datastores, err := c.gs.GetDatastores(c.workspace)
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(datastores))
+}
+
+func getDatastore(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	datastore, err := c.gs.GetDatastoreDetails(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(datastore)
+}
+
+func deleteDatastore(c *cli, args []string) error {
+	flags := noFlags("delete")
+	recurse := flags.Bool("recurse", false, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteDatastore(c.workspace, args[0], *recurse); err != nil {
+		return err
+	}
+	return c.out.printDone("datastore %s:%s deleted", c.workspace, args[0])
+}
+
+func listFeatureTypes(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("list"), args, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	featureTypes, err := c.gs.GetFeatureTypes(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(featureTypes))
+}
+
+func getFeatureType(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 2)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	featureType, err := c.gs.GetFeatureType(c.workspace, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(featureType)
+}
+
+func deleteFeatureType(c *cli, args []string) error {
+	flags := noFlags("delete")
+	recurse := flags.Bool("recurse", false, "")
+	args, err := parseArgs(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteFeatureType(c.workspace, args[0], args[1], *recurse); err != nil {
+		return err
+	}
+	return c.out.printDone("featuretype %s:%s deleted", c.workspace, args[1])
+}
+
+func listCoverages(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	if err := c.requireWorkspace(); err != nil {
+		return err
+	}
+	coverages, err := c.gs.GetCoverages(c.workspace)
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(coverages))
+}
+
+func getCoverage(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	coverage, err := c.gs.GetCoverage(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(coverage)
+}
+
+func deleteCoverage(c *cli, args []string) error {
+	flags := noFlags("delete")
+	recurse := flags.Bool("recurse", false, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.requireWorkspace(); err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteCoverage(c.workspace, args[0], *recurse); err != nil {
+		return err
+	}
+	return c.out.printDone("coverage %s:%s deleted", c.workspace, args[0])
+}
+
+func listLayers(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	layers, err := c.gs.GetLayers(c.workspace)
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(layers))
+}
+
+func getLayer(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	layer, err := c.gs.GetLayer(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(layer)
+}
+
+func deleteLayer(c *cli, args []string) error {
+	flags := noFlags("delete")
+	recurse := flags.Bool("recurse", false, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteLayer(c.workspace, args[0], *recurse); err != nil {
+		return err
+	}
+	return c.out.printDone("layer %s deleted", args[0])
+}
+
+func setLayerStyle(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("set-style"), args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.SetLayerDefaultStyle(c.workspace, args[0], args[1]); err != nil {
+		return err
+	}
+	return c.out.printDone("layer %s default style is set to %s", args[0], args[1])
+}
+
+func listLayerGroups(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	layerGroups, err := c.gs.GetLayerGroups(c.workspace)
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(layerGroups))
+}
+
+func getLayerGroup(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	layerGroup, err := c.gs.GetLayerGroup(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printObject(layerGroup)
+}
+
+func deleteLayerGroup(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteLayerGroup(c.workspace, args[0]); err != nil {
+		return err
+	}
+	return c.out.printDone("layergroup %s deleted", args[0])
+}
+
+func listStyles(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	styles, err := c.gs.GetStyles(c.workspace)
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(resourcesTable(styles))
+}
+
+// getStyle prints the style definition in table format, the style info otherwise
+func getStyle(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("get"), args, 1)
+	if err != nil {
+		return err
+	}
+	if c.out.format != outputTable {
+		style, err := c.gs.GetStyle(c.workspace, args[0])
+		if err != nil {
+			return err
+		}
+		return c.out.printObject(style)
+	}
+	body, err := c.gs.GetStyleBody(c.workspace, args[0])
+	if err != nil {
+		return err
+	}
+	_, err = c.out.out.Write(body)
+	return err
+}
+
+func uploadStyle(c *cli, args []string) error {
+	flags := noFlags("upload")
+	overwrite := flags.Bool("overwrite", false, "")
+	args, err := parseArgs(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = c.gs.UploadStyle(file, c.workspace, args[0], *overwrite); err != nil {
+		return err
+	}
+	return c.out.printDone("style %s uploaded", args[0])
+}
+
+func deleteStyle(c *cli, args []string) error {
+	flags := noFlags("delete")
+	purge := flags.Bool("purge", false, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteStyle(c.workspace, args[0], *purge); err != nil {
+		return err
+	}
+	return c.out.printDone("style %s deleted", args[0])
+}
+
+func listAclRules(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	rules, err := c.gs.GetLayersAclRules()
+	if err != nil {
+		return err
+	}
+	t := table{headers: []string{"RULE", "ROLES"}, data: rules}
+	for _, r := range rules {
+		rule, roles := r.ToStrings()
+		t.rows = append(t.rows, []string{rule, roles})
+	}
+	return c.out.printTable(t)
+}
+
+func aclRuleArgs(args []string, count int) (rule geoserver.AclRule, err error) {
+	args, err = parseArgs(noFlags("acl"), args, count)
+	if err != nil {
+		return
+	}
+	roles := ""
+	if count > 1 {
+		roles = args[1]
+	}
+	rule, err = geoserver.StringToAclRule(args[0], roles)
+	if err != nil {
+		return rule, usageErrorf("%v: %s", err, args[0])
+	}
+	return
+}
+
+func addAclRule(c *cli, args []string) error {
+	rule, err := aclRuleArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.AddLayersAclRule(rule); err != nil {
+		return err
+	}
+	return c.out.printDone("acl rule %s added", args[0])
+}
+
+func updateAclRule(c *cli, args []string) error {
+	rule, err := aclRuleArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.UpdateLayersAclRule(rule); err != nil {
+		return err
+	}
+	return c.out.printDone("acl rule %s updated", args[0])
+}
+
+func deleteAclRule(c *cli, args []string) error {
+	rule, err := aclRuleArgs(args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteLayersAclRule(rule); err != nil {
+		return err
+	}
+	return c.out.printDone("acl rule %s deleted", args[0])
+}
+
+func listUsers(c *cli, args []string) error {
+	flags := noFlags("list")
+	service := flags.String("service", "", "")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	users, err := c.gs.GetUsers(*service)
+	if err != nil {
+		return err
+	}
+	t := table{headers: []string{"NAME", "ENABLED"}, data: users}
+	for _, u := range users {
+		t.rows = append(t.rows, []string{u.Name, strconv.FormatBool(u.Enabled)})
+	}
+	return c.out.printTable(t)
+}
+
+func createUser(c *cli, args []string) error {
+	flags := noFlags("create")
+	service := flags.String("service", "", "")
+	passwordFile := flags.String("password-file", "", "")
+	passwordEnv := flags.String("password-env", "", "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if *passwordFile == "" && *passwordEnv == "" {
+		*passwordFile = "-"
+	}
+	password, err := readPassword(c.in, *passwordFile, *passwordEnv)
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return usageErrorf("password of user %s is empty", args[0])
+	}
+	if _, err = c.gs.CreateUser(args[0], password, *service); err != nil {
+		return err
+	}
+	return c.out.printDone("user %s created", args[0])
+}
+
+func deleteUser(c *cli, args []string) error {
+	flags := noFlags("delete")
+	service := flags.String("service", "", "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteUser(args[0], *service); err != nil {
+		return err
+	}
+	return c.out.printDone("user %s deleted", args[0])
+}
+
+func listUserRoles(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("roles"), args, 1)
+	if err != nil {
+		return err
+	}
+	roles, err := c.gs.GetUserRoles(args[0])
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(namesTable("ROLE", roles))
+}
+
+func listRoles(c *cli, args []string) error {
+	if _, err := parseArgs(noFlags("list"), args, 0); err != nil {
+		return err
+	}
+	roles, err := c.gs.GetRoles()
+	if err != nil {
+		return err
+	}
+	return c.out.printTable(namesTable("ROLE", roles))
+}
+
+func createRole(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("create"), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.CreateRole(args[0]); err != nil {
+		return err
+	}
+	return c.out.printDone("role %s created", args[0])
+}
+
+func deleteRole(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("delete"), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteRole(args[0]); err != nil {
+		return err
+	}
+	return c.out.printDone("role %s deleted", args[0])
+}
+
+func assignRole(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("assign"), args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.AddUserRole(args[0], args[1]); err != nil {
+		return err
+	}
+	return c.out.printDone("role %s assigned to %s", args[0], args[1])
+}
+
+func unassignRole(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("unassign"), args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err = c.gs.DeleteUserRole(args[0], args[1]); err != nil {
+		return err
+	}
+	return c.out.printDone("role %s unassigned from %s", args[0], args[1])
+}
+
+func seedLayer(c *cli, args []string) error {
+	flags := noFlags("seed")
+	request := geoserver.GwcSeedRequest{}
+	flags.StringVar(&request.Type, "type", "seed", "")
+	flags.StringVar(&request.GridsetId, "gridset", "EPSG:900913", "")
+	flags.StringVar(&request.Format, "format", "image/png", "")
+	flags.IntVar(&request.ZoomStart, "zoom-start", 0, "")
+	flags.IntVar(&request.ZoomStop, "zoom-stop", 10, "")
+	flags.IntVar(&request.ThreadCount, "threads", 1, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	workspaceName, layerName := splitLayerName(c.workspace, args[0])
+	if workspaceName == "" {
+		return usageErrorf("workspace is required, use -w or ${workspace}:${layer}")
+	}
+	if err = c.gs.GwcSeedRequest(workspaceName, layerName, request); err != nil {
+		return err
+	}
+	return c.out.printDone("%s of %s:%s is started", request.Type, workspaceName, layerName)
+}
+
+func listSeedTasks(c *cli, args []string) error {
+	args, err := parseArgs(noFlags("tasks"), args, 1)
+	if err != nil {
+		return err
+	}
+	workspaceName, layerName := splitLayerName(c.workspace, args[0])
+	if workspaceName == "" {
+		return usageErrorf("workspace is required, use -w or ${workspace}:${layer}")
+	}
+	tasks, err := c.gs.GwcTasks(workspaceName, layerName)
+	if err != nil {
+		return err
+	}
+	t := table{headers: []string{"ID", "STATUS", "PROCESSED", "TOTAL", "REMAINING"}, data: tasks}
+	for _, task := range tasks {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(task.Id), strconv.Itoa(int(task.Status)),
+			strconv.Itoa(task.TilesProcessed), strconv.Itoa(task.TilesTotal), strconv.Itoa(task.TilesRemaining),
+		})
+	}
+	return c.out.printTable(t)
+}
+
+// splitLayerName splits ${workspace}:${layer}, the default workspace is used for unqualified names
+func splitLayerName(defaultWorkspace string, name string) (workspaceName string, layerName string) {
+	if parts := strings.SplitN(name, ":", 2); len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return defaultWorkspace, name
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/archer-v/geoserver"
+)
+
+// defaultConfigFile returns $GSCTL_CONFIG or ~/.gsctl.yml
+func defaultConfigFile() string {
+	if file := os.Getenv("GSCTL_CONFIG"); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gsctl.yml")
+}
+
+// loadProfile reads the profile from the config file (see geoserver.LoadClientConfig),
+// the missing default config file isn't an error
+func loadProfile(configFile string, profileName string) (p geoserver.ClientProfile, err error) {
+	explicit := configFile != ""
+	if !explicit {
+		configFile = defaultConfigFile()
+		if _, err = os.Stat(configFile); os.IsNotExist(err) {
+			return p, nil
+		}
+	}
+	config, err := geoserver.LoadClientConfig(configFile)
+	if err != nil {
+		return p, err
+	}
+	return config.Profile(profileName)
+}
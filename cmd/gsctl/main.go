@@ -0,0 +1,242 @@
+// Command gsctl manages the GeoServer catalog from the command line.
+//
+// Usage:
+//
+//	gsctl [flags] <resource> <action> [action flags] [args]
+//
+// The connection is read from the profile of the config file (~/.gsctl.yml or $GSCTL_CONFIG),
+// see geoserver.LoadClientConfig:
+//
+//	default: staging
+//	profiles:
+//	  staging:
+//	    geoserver_url: http://localhost:8080/geoserver/
+//	    username: admin
+//	    password_env: GEOSERVER_PASSWORD
+//	    workspace: topp
+//
+// and can be overridden by -url, -user, -password-file (- reads stdin), -password-env and -w flags,
+// the password isn't accepted on the command line.
+// The exit code is 0 on success, 2 on usage error, 3 if the resource isn't found,
+// 4 if the access is denied, 5 on geoserver internal error and 1 on other errors.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/archer-v/geoserver"
+)
+
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitDenied
+	exitServerError
+)
+
+// usageError is the error of the command line arguments
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps the error to the exit code
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var usageErr usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+	var gsErr geoserver.GsError
+	if errors.As(err, &gsErr) {
+		switch status := gsErr.StatusCode(); {
+		case status == 404:
+			return exitNotFound
+		case status == 401 || status == 403:
+			return exitDenied
+		case status >= 500:
+			return exitServerError
+		}
+	}
+	return exitError
+}
+
+// cli is the state of the command execution
+type cli struct {
+	gs        *geoserver.GeoServer
+	workspace string
+	in        io.Reader
+	out       *printer
+}
+
+// command is the action of the resource, usage describes the action arguments
+type command struct {
+	usage string
+	run   func(c *cli, args []string) error
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(arguments []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gsctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configFile := flags.String("config", "", "config file (default $GSCTL_CONFIG or ~/.gsctl.yml)")
+	profileName := flags.String("profile", os.Getenv("GSCTL_PROFILE"), "config profile")
+	serverURL := flags.String("url", "", "geoserver url, e.g. http://localhost:8080/geoserver/")
+	username := flags.String("user", "", "geoserver user")
+	passwordFile := flags.String("password-file", "", "file with geoserver password, - reads it from stdin")
+	passwordEnv := flags.String("password-env", "", "environment variable with geoserver password")
+	workspace := flags.String("w", "", "workspace")
+	format := flags.String("o", outputTable, "output format: table, json or yaml")
+	verbose := flags.Bool("v", false, "log geoserver requests to stderr")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: gsctl [flags] <resource> <action> [action flags] [args]")
+		flags.PrintDefaults()
+		fmt.Fprintln(stderr, "\nresources and actions:")
+		printCommands(stderr)
+	}
+	if err := flags.Parse(arguments); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	p, err := loadProfile(*configFile, *profileName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	for _, override := range []struct{ value, target *string }{
+		{serverURL, &p.ServerURL}, {username, &p.Username}, {workspace, &p.WorkspaceName},
+	} {
+		if *override.value != "" {
+			*override.target = *override.value
+		}
+	}
+	if *passwordFile != "" || *passwordEnv != "" {
+		if p.Password, err = readPassword(stdin, *passwordFile, *passwordEnv); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+		p.PasswordFile, p.PasswordEnv = "", ""
+	}
+	if p.ServerURL == "" {
+		fmt.Fprintln(stderr, "geoserver url isn't configured, use -url or the config profile")
+		return exitUsage
+	}
+	if !*verbose {
+		if geoserver.LogFile, err = os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+			geoserver.LogConsoleQuiet = true
+		}
+	}
+	gs, err := p.NewCatalog()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	c := &cli{
+		gs:        gs,
+		workspace: p.WorkspaceName,
+		in:        stdin,
+		out:       &printer{out: stdout, format: *format},
+	}
+	if err = c.execute(flags.Arg(0), flags.Arg(1), flags.Args()[2:]); err != nil {
+		fmt.Fprintf(stderr, "gsctl: %v\n", err)
+	}
+	return exitCode(err)
+}
+
+func (c *cli) execute(resource string, action string, args []string) error {
+	actions, ok := commands[resource]
+	if !ok {
+		return usageErrorf("unknown resource %s", resource)
+	}
+	cmd, ok := actions[action]
+	if !ok {
+		return usageErrorf("unknown action %s %s", resource, action)
+	}
+	err := cmd.run(c, args)
+	var usageErr usageError
+	if errors.As(err, &usageErr) {
+		return usageErrorf("%v, usage: gsctl %s %s %s", err, resource, action, cmd.usage)
+	}
+	return err
+}
+
+// requireWorkspace returns an error if the workspace isn't set by -w or the profile
+func (c *cli) requireWorkspace() error {
+	if c.workspace == "" {
+		return usageErrorf("workspace is required, use -w or the config profile")
+	}
+	return nil
+}
+
+// readPassword reads the password from the file (- is stdin) or the environment variable,
+// see geoserver.ClientProfile.ResolvePassword
+func readPassword(stdin io.Reader, file string, env string) (string, error) {
+	if file != "-" {
+		return geoserver.ClientProfile{PasswordFile: file, PasswordEnv: env}.ResolvePassword()
+	}
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("can't read password from stdin: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// parseArgs parses the action flags and checks the number of the positional arguments
+func parseArgs(flags *flag.FlagSet, args []string, count int) ([]string, error) {
+	flags.SetOutput(ioutil.Discard)
+	if err := flags.Parse(args); err != nil {
+		return nil, usageError{message: err.Error()}
+	}
+	if flags.NArg() != count {
+		return nil, usageErrorf("%d arguments expected", count)
+	}
+	return flags.Args(), nil
+}
+
+func printCommands(w io.Writer) {
+	resources := make([]string, 0, len(commands))
+	for resource := range commands {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	for _, resource := range resources {
+		actions := make([]string, 0, len(commands[resource]))
+		for action := range commands[resource] {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+		for _, action := range actions {
+			fmt.Fprintf(w, "  %s %s %s\n", resource, action, commands[resource][action].usage)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/geoserver/rest/workspaces":
+			w.Write([]byte(`{"workspaces":{"workspace":[{"name":"topp"},{"name":"tiger"}]}}`))
+		case "/geoserver/rest/security/acl/layers":
+			w.Write([]byte(`{"topp.*.r":"ROLE_A"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "gsctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.yml")
+	assert.Nil(t, ioutil.WriteFile(configFile, []byte("default: test\nprofiles:\n  test:\n    geoserver_url: "+server.URL+"/geoserver\n    workspace: topp\n"), 0644))
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-config", configFile, "workspaces", "list"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, "NAME\ntopp\ntiger\n", stdout.String())
+
+	stdout.Reset()
+	code = run([]string{"-config", configFile, "-o", "json", "acl", "list"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), `"Workspace": "topp"`)
+
+	code = run([]string{"-config", configFile, "layers", "get", "roads"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitNotFound, code)
+
+	stderr.Reset()
+	code = run([]string{"-config", configFile, "layers", "get"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr.String(), "usage: gsctl layers get <layer>")
+
+	code = run([]string{"-config", configFile, "-profile", "missing", "layers", "list"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestRunPasswords(t *testing.T) {
+	var passwords []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, _ := r.BasicAuth()
+		assert.Equal(t, "connection-secret", password)
+		var body struct {
+			User struct {
+				Password string `json:"password"`
+			} `json:"user"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		passwords = append(passwords, body.User.Password)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	os.Setenv("GSCTL_TEST_PASSWORD", "connection-secret")
+	os.Setenv("GSCTL_TEST_USER_PASSWORD", "env-secret")
+	defer os.Unsetenv("GSCTL_TEST_PASSWORD")
+	defer os.Unsetenv("GSCTL_TEST_USER_PASSWORD")
+	dir, err := ioutil.TempDir("", "gsctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.yml")
+	assert.Nil(t, ioutil.WriteFile(configFile, []byte("{}\n"), 0644))
+	connection := []string{"-config", configFile, "-url", server.URL + "/geoserver/", "-user", "admin"}
+
+	var stdout, stderr bytes.Buffer
+	code := run(append(connection, "-password-env", "GSCTL_TEST_PASSWORD", "users", "create", "parks"), strings.NewReader("stdin-secret\n"), &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	code = run(append(connection, "-password-file", "-", "users", "create", "-password-env", "GSCTL_TEST_USER_PASSWORD", "parks"), strings.NewReader("connection-secret\n"), &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, []string{"stdin-secret", "env-secret"}, passwords)
+
+	code = run(append(connection, "-password-env", "GSCTL_TEST_PASSWORD", "users", "create", "parks"), strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, exitUsage, code)
+	code = run(append(connection, "-password", "secret", "users", "create", "parks"), strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, exitUsage, code)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// printer writes the command results in the selected format
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// table is the tabular view of the result, data is written instead of the rows in json and yaml formats
+type table struct {
+	headers []string
+	rows    [][]string
+	data    interface{}
+}
+
+func (p *printer) printTable(t table) error {
+	if p.format != outputTable {
+		return p.printObject(t.data)
+	}
+	w := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printObject writes the object, table format of the single object is yaml
+func (p *printer) printObject(object interface{}) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch p.format {
+	case outputJSON:
+		data, err = json.MarshalIndent(object, "", "  ")
+		data = append(data, '\n')
+	case outputYAML, outputTable:
+		data, err = yaml.Marshal(object)
+	default:
+		return usageErrorf("unknown output format %s", p.format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = p.out.Write(data)
+	return err
+}
+
+// printDone reports the successful modification
+func (p *printer) printDone(format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if p.format != outputTable {
+		return p.printObject(map[string]string{"result": message})
+	}
+	_, err := fmt.Fprintln(p.out, message)
+	return err
+}
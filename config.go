@@ -0,0 +1,282 @@
+package geoserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// defaultResponseHeaderTimeout is the response header timeout of the client created by GetCatalog
+const defaultResponseHeaderTimeout = time.Second * 5
+
+// TLSConfig configures the https connection to geoserver,
+// CAFile is the pem file with the certificates of the trusted authorities (in addition to the system ones),
+// CertFile and KeyFile are the client certificate
+type TLSConfig struct {
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// RetryConfig configures retrying of the idempotent requests failed with network error or 502, 503, 504 status,
+// Attempts is the total number of attempts (1 or 0 means no retries), Backoff is the delay before the first retry,
+// the delay is doubled for every next retry
+type RetryConfig struct {
+	Attempts int           `yaml:"attempts"`
+	Backoff  time.Duration `yaml:"backoff"`
+}
+
+// ClientProfile is the geoserver connection settings,
+// the password is taken from Password, the file PasswordFile or the environment variable PasswordEnv (the first not empty),
+// Timeout is the whole request timeout (no timeout if 0), ResponseHeaderTimeout is 5s if not set,
+// durations are given as "10s", "1m"
+type ClientProfile struct {
+	WorkspaceName         string        `yaml:"workspace"`
+	ServerURL             string        `yaml:"geoserver_url"`
+	Username              string        `yaml:"username"`
+	Password              string        `yaml:"password"`
+	PasswordFile          string        `yaml:"password_file"`
+	PasswordEnv           string        `yaml:"password_env"`
+	TLS                   TLSConfig     `yaml:"tls"`
+	Timeout               time.Duration `yaml:"timeout"`
+	ResponseHeaderTimeout time.Duration `yaml:"response_header_timeout"`
+	Retry                 RetryConfig   `yaml:"retry"`
+}
+
+// ClientConfig is the configuration file with the named profiles, Default is the name of the profile used by default,
+// a file without profiles (e.g. the file read by GeoServer.LoadConfig) is the single default profile,
+// ${VAR} and $VAR in the values are replaced by the environment variables, $$ is the literal $,
+// the password is taken literally (use password_env for the password from the environment)
+type ClientConfig struct {
+	Default       string                   `yaml:"default"`
+	Profiles      map[string]ClientProfile `yaml:"profiles"`
+	ClientProfile `yaml:",inline"`
+}
+
+// LoadClientConfig loads the configuration file expanding the environment variables,
+// err is an error if the file can't be read or parsed or it references undefined environment variables
+func LoadClientConfig(configFile string) (config *ClientConfig, err error) {
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	config = &ClientConfig{}
+	if err = yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("can't parse config %s: %v", configFile, err)
+	}
+	if err = config.ClientProfile.expandEnv(); err != nil {
+		return nil, fmt.Errorf("config %s: %v", configFile, err)
+	}
+	for name, p := range config.Profiles {
+		if err = p.expandEnv(); err != nil {
+			return nil, fmt.Errorf("config %s profile %s: %v", configFile, name, err)
+		}
+		config.Profiles[name] = p
+	}
+	return config, nil
+}
+
+// Profile returns the named profile, name "" means the default profile
+func (c *ClientConfig) Profile(name string) (profile ClientProfile, err error) {
+	if name == "" {
+		name = c.Default
+	}
+	if name == "" {
+		return c.ClientProfile, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return profile, fmt.Errorf("profile %s isn't found", name)
+	}
+	return profile, nil
+}
+
+// LoadProfile loads the named profile from the configuration file and returns the catalog configured by the profile
+func LoadProfile(configFile string, name string) (catalog *GeoServer, err error) {
+	config, err := LoadClientConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := config.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewCatalog()
+}
+
+// expandEnv replaces the environment variables in the string values of the profile except the password
+func (p *ClientProfile) expandEnv() error {
+	for _, value := range []*string{
+		&p.WorkspaceName, &p.ServerURL, &p.Username, &p.PasswordFile, &p.PasswordEnv,
+		&p.TLS.CAFile, &p.TLS.CertFile, &p.TLS.KeyFile, &p.TLS.ServerName,
+	} {
+		var missing []string
+		*value = os.Expand(*value, func(name string) string {
+			if name == "$" {
+				return "$"
+			}
+			v, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return v
+		})
+		if len(missing) != 0 {
+			return fmt.Errorf("environment variable %s isn't defined", strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
+
+// ResolvePassword returns the password from Password, PasswordFile or PasswordEnv,
+// the trailing new line of the password file is trimmed
+func (p ClientProfile) ResolvePassword() (password string, err error) {
+	switch {
+	case p.Password != "":
+		return p.Password, nil
+	case p.PasswordFile != "":
+		data, err := ioutil.ReadFile(p.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("can't read password file: %v", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case p.PasswordEnv != "":
+		password, ok := os.LookupEnv(p.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("password environment variable %s isn't defined", p.PasswordEnv)
+		}
+		return password, nil
+	}
+	return "", nil
+}
+
+// NewCatalog returns the catalog configured by the profile
+func (p ClientProfile) NewCatalog() (catalog *GeoServer, err error) {
+	if p.ServerURL == "" {
+		return nil, fmt.Errorf("geoserver_url isn't set")
+	}
+	password, err := p.ResolvePassword()
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	serverURL := p.ServerURL
+	if !strings.HasSuffix(serverURL, "/") {
+		serverURL += "/"
+	}
+	catalog = GetCatalog(serverURL, p.Username, password)
+	catalog.WorkspaceName = p.WorkspaceName
+	catalog.HttpClient = client
+	return catalog, nil
+}
+
+func (p ClientProfile) httpClient() (*http.Client, error) {
+	tlsConfig, err := p.TLS.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	responseHeaderTimeout := p.ResponseHeaderTimeout
+	if responseHeaderTimeout == 0 {
+		responseHeaderTimeout = defaultResponseHeaderTimeout
+	}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DisableCompression:    true, // gzip compression is disabled, cause GWC has an issue with erroneous responses
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		TLSClientConfig:       tlsConfig,
+	}
+	if p.Retry.Attempts > 1 {
+		transport = &retryTransport{next: transport, retry: p.Retry}
+	}
+	return &http.Client{Transport: transport, Timeout: p.Timeout}, nil
+}
+
+// tlsConfig returns nil if no tls options are set
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	if c == (TLSConfig{}) {
+		return nil, nil
+	}
+	config := &tls.Config{ServerName: c.ServerName, InsecureSkipVerify: c.InsecureSkipVerify}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read ca file: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca file %s", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// retryTransport retries the idempotent requests failed with network error or the gateway errors
+type retryTransport struct {
+	next  http.RoundTripper
+	retry RetryConfig
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	retryable := request.Method == getMethod || request.Method == putMethod || request.Method == deleteMethod || request.Method == http.MethodHead
+	if request.Body != nil && request.GetBody == nil {
+		retryable = false
+	}
+	backoff := t.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		response, err = t.next.RoundTrip(request)
+		if !retryable || attempt >= t.retry.Attempts || !retryableResponse(response, err) {
+			return response, err
+		}
+		if response != nil {
+			response.Body.Close()
+		}
+		select {
+		case <-time.After(backoff):
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+		backoff *= 2
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+	}
+}
+
+func retryableResponse(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package geoserver
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestConfig(t *testing.T, dir string, name string, content string) string {
+	file := filepath.Join(dir, name)
+	assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+func TestLoadClientConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	os.Setenv("GS_TEST_HOST", "gs.example.com")
+	os.Setenv("GS_TEST_PASSWORD", "from-env")
+	defer os.Unsetenv("GS_TEST_HOST")
+	defer os.Unsetenv("GS_TEST_PASSWORD")
+	passwordFile := writeTestConfig(t, dir, "password", "from-file\n")
+
+	configFile := writeTestConfig(t, dir, "config.yml", `
+default: staging
+profiles:
+  staging:
+    geoserver_url: https://${GS_TEST_HOST}/geoserver
+    username: admin
+    password_file: `+passwordFile+`
+    timeout: 30s
+    retry:
+      attempts: 3
+      backoff: 100ms
+  prod:
+    geoserver_url: https://$GS_TEST_HOST/geoserver/
+    username: admin
+    password_env: GS_TEST_PASSWORD
+`)
+	config, err := LoadClientConfig(configFile)
+	assert.Nil(t, err)
+	staging, err := config.Profile("")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gs.example.com/geoserver", staging.ServerURL)
+	assert.Equal(t, 30*time.Second, staging.Timeout)
+	assert.Equal(t, RetryConfig{Attempts: 3, Backoff: 100 * time.Millisecond}, staging.Retry)
+	catalog, err := staging.NewCatalog()
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gs.example.com/geoserver/", catalog.ServerURL)
+	assert.Equal(t, "from-file", catalog.Password)
+	assert.Equal(t, 30*time.Second, catalog.HttpClient.Timeout)
+
+	catalog, err = LoadProfile(configFile, "prod")
+	assert.Nil(t, err)
+	assert.Equal(t, "from-env", catalog.Password)
+
+	_, err = config.Profile("missing")
+	assert.NotNil(t, err)
+
+	// LoadConfig format without profiles
+	catalog, err = LoadProfile(writeTestConfig(t, dir, "single.yml", "geoserver_url: http://localhost:8080/geoserver/\nworkspace: golang\n"), "")
+	assert.Nil(t, err)
+	assert.Equal(t, "golang", catalog.WorkspaceName)
+
+	// the password isn't expanded, $$ escapes $ in the other values
+	catalog, err = LoadProfile(writeTestConfig(t, dir, "literal.yml", "geoserver_url: http://localhost:8080/geoserver/\nusername: ad$$min\npassword: pa$$w0rd${GS_TEST_HOST}\n"), "")
+	assert.Nil(t, err)
+	assert.Equal(t, "ad$min", catalog.Username)
+	assert.Equal(t, "pa$$w0rd${GS_TEST_HOST}", catalog.Password)
+
+	_, err = LoadClientConfig(writeTestConfig(t, dir, "undefined.yml", "geoserver_url: http://${GS_TEST_UNDEFINED}/geoserver/\n"))
+	assert.NotNil(t, err)
+	_, err = LoadClientConfig(filepath.Join(dir, "missing.yml"))
+	assert.NotNil(t, err)
+}
+
+func TestClientProfileTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"workspaces":{"workspace":[{"name":"topp"}]}}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	caFile := writeTestConfig(t, dir, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+
+	profile := ClientProfile{ServerURL: server.URL, TLS: TLSConfig{CAFile: caFile}, Retry: RetryConfig{Attempts: 3, Backoff: time.Millisecond}}
+	catalog, err := profile.NewCatalog()
+	assert.Nil(t, err)
+	workspaces, err := catalog.GetWorkspaces()
+	assert.Nil(t, err)
+	assert.Len(t, workspaces, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// the server certificate isn't trusted without the ca file
+	profile.TLS = TLSConfig{}
+	catalog, err = profile.NewCatalog()
+	assert.Nil(t, err)
+	_, err = catalog.GetWorkspaces()
+	assert.NotNil(t, err)
+
+	profile.TLS = TLSConfig{CAFile: filepath.Join(dir, "missing.pem")}
+	_, err = profile.NewCatalog()
+	assert.NotNil(t, err)
+}
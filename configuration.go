@@ -1,11 +1,42 @@
 package geoserver
 
-import "bytes"
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+const (
+	LoggingDefault            = "DEFAULT_LOGGING"
+	LoggingVerbose            = "VERBOSE_LOGGING"
+	LoggingProduction         = "PRODUCTION_LOGGING"
+	LoggingQuiet              = "QUIET_LOGGING"
+	LoggingTest               = "TEST_LOGGING"
+	LoggingGeoServerDeveloper = "GEOSERVER_DEVELOPER_LOGGING"
+	LoggingGeoToolsDeveloper  = "GEOTOOLS_DEVELOPER_LOGGING"
+)
 
 // ConfigurationService define geoserver Configuration operations
 type ConfigurationService interface {
 	RestConfigrationCache() (success bool, err error)
 	ReloadConfigration() (success bool, err error)
+	GetLogging() (logging *LoggingSettings, err error)
+	UpdateLogging(logging LoggingSettings, fields ...string) (modified bool, err error)
+	SetLoggingLevelFor(level string, window time.Duration) (revert func() error, err error)
+}
+
+// LoggingSettings is geoserver logging configuration,
+// Level is the logging profile, one of Logging* constants (some geoserver versions add ".properties" suffix),
+// Location is the log file path, relative paths are resolved against the data directory
+type LoggingSettings struct {
+	Level         string `json:"level,omitempty"`
+	Location      string `json:"location,omitempty"`
+	StdOutLogging *bool  `json:"stdOutLogging,omitempty"`
+}
+
+// LoggingRequestBody is geoserver logging configuration to send to api
+type LoggingRequestBody struct {
+	Logging *LoggingSettings `json:"logging,omitempty"`
 }
 
 //RestConfigrationCache Resets all store, raster, and schema caches.
@@ -56,3 +87,75 @@ func (g *GeoServer) ReloadConfigration() (success bool, err error) {
 	success = true
 	return
 }
+
+// GetLogging returns geoserver logging configuration,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetLogging() (logging *LoggingSettings, err error) {
+	targetURL := g.ParseURL("rest", "logging")
+	var loggingResponse LoggingRequestBody
+	if err = g.requestResource(targetURL, &loggingResponse); err != nil {
+		return nil, err
+	}
+	return loggingResponse.Logging, nil
+}
+
+// UpdateLogging partial update geoserver logging configuration else return error,
+// fields is an optional field mask, a list of json field names to send even if they are zero or nil (e.g. "stdOutLogging"),
+// geoserver replaces the whole logging configuration, so the fields are merged into the current settings
+// and the settings not set in the logging are kept
+func (g *GeoServer) UpdateLogging(logging LoggingSettings, fields ...string) (modified bool, err error) {
+	patch, err := patchEntity(logging, fields)
+	if err != nil {
+		return false, err
+	}
+	current, err := g.GetLogging()
+	if err != nil {
+		return false, err
+	}
+	merged, err := mergeEntity(current, patch)
+	if err != nil {
+		return false, err
+	}
+	targetURL := g.ParseURL("rest", "logging")
+	return g.updateSettingsEntity(targetURL, map[string]interface{}{"logging": merged})
+}
+
+// SetLoggingLevelFor switches geoserver to the logging profile level (e.g. LoggingVerbose) for the window
+// and reverts the previous settings automatically when the window elapses, the other logging settings are kept,
+// revert restores the previous profile earlier, it's safe to call it several times, only the first call takes effect,
+// an error of the automatic revert is logged
+func (g *GeoServer) SetLoggingLevelFor(level string, window time.Duration) (revert func() error, err error) {
+	current, err := g.GetLogging()
+	if err != nil {
+		return nil, err
+	}
+	// geoserver replaces the whole logging configuration, so the full settings are sent with only the level changed
+	targetURL := g.ParseURL("rest", "logging")
+	previous := current.Level
+	changed := *current
+	changed.Level = level
+	if _, err = g.updateSettingsEntity(targetURL, LoggingRequestBody{Logging: &changed}); err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	var revertErr error
+	done := make(chan struct{})
+	revert = func() error {
+		once.Do(func() {
+			close(done)
+			_, revertErr = g.updateSettingsEntity(targetURL, LoggingRequestBody{Logging: current})
+		})
+		return revertErr
+	}
+	go func() {
+		select {
+		case <-time.After(window):
+			if err := revert(); err != nil {
+				g.logger.Errorf("can't revert logging level to %s: %v", previous, err)
+			}
+		case <-done:
+		}
+	}()
+	return revert, nil
+}
@@ -1,10 +1,17 @@
 package geoserver
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRestConfigrationCache(t *testing.T) {
@@ -28,6 +35,95 @@ func TestReloadConfigration(t *testing.T) {
 	assert.False(t, successF)
 	assert.NotNil(t, errF)
 }
+func TestLogging(t *testing.T) {
+	test_before(t)
+	logging, err := gsCatalog.GetLogging()
+	require.NoError(t, err)
+	assert.NotEmpty(t, logging.Level)
+	defer func() {
+		_, _ = gsCatalog.UpdateLogging(*logging, "level", "stdOutLogging")
+	}()
+
+	modified, err := gsCatalog.UpdateLogging(LoggingSettings{StdOutLogging: BoolPtr(true)})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	updated, err := gsCatalog.GetLogging()
+	require.NoError(t, err)
+	assert.True(t, *updated.StdOutLogging)
+	assert.Equal(t, logging.Level, updated.Level)
+}
+
+func TestUpdateLogging(t *testing.T) {
+	// geoserver replaces the stored settings by the sent ones
+	stored := LoggingSettings{Level: LoggingDefault, Location: "logs/geoserver.log", StdOutLogging: BoolPtr(true)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			var request LoggingRequestBody
+			_ = json.Unmarshal(body, &request)
+			stored = *request.Logging
+		}
+		data, _ := json.Marshal(LoggingRequestBody{Logging: &stored})
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	modified, err := gsCatalog.UpdateLogging(LoggingSettings{StdOutLogging: BoolPtr(false)})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, LoggingSettings{Level: LoggingDefault, Location: "logs/geoserver.log", StdOutLogging: BoolPtr(false)}, stored)
+
+	modified, err = gsCatalog.UpdateLogging(LoggingSettings{Level: LoggingVerbose}, "location")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, LoggingSettings{Level: LoggingVerbose, StdOutLogging: BoolPtr(false)}, stored)
+}
+
+func TestSetLoggingLevelFor(t *testing.T) {
+	var mu sync.Mutex
+	// geoserver replaces the stored settings by the sent ones
+	stored := LoggingSettings{Level: LoggingDefault, Location: "logs/geoserver.log", StdOutLogging: BoolPtr(true)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			var request LoggingRequestBody
+			_ = json.Unmarshal(body, &request)
+			stored = LoggingSettings{Level: request.Logging.Level, Location: request.Logging.Location, StdOutLogging: BoolPtr(false)}
+			if request.Logging.StdOutLogging != nil {
+				stored.StdOutLogging = request.Logging.StdOutLogging
+			}
+		}
+		data, _ := json.Marshal(LoggingRequestBody{Logging: &stored})
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+	current := func() LoggingSettings {
+		mu.Lock()
+		defer mu.Unlock()
+		return stored
+	}
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	revert, err := gsCatalog.SetLoggingLevelFor(LoggingVerbose, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, LoggingSettings{Level: LoggingVerbose, Location: "logs/geoserver.log", StdOutLogging: BoolPtr(true)}, current())
+	assert.Nil(t, revert())
+	assert.Equal(t, LoggingSettings{Level: LoggingDefault, Location: "logs/geoserver.log", StdOutLogging: BoolPtr(true)}, current())
+	assert.Nil(t, revert())
+
+	_, err = gsCatalog.SetLoggingLevelFor(LoggingVerbose, 10*time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, LoggingVerbose, current().Level)
+	for i := 0; i < 100 && current().Level != LoggingDefault; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, LoggingDefault, current().Level)
+	assert.True(t, *current().StdOutLogging)
+}
+
 func TestConfigurationServiceImplemet(t *testing.T) {
 	gsCatalog := reflect.TypeOf(&GeoServer{})
 	CatalogType := reflect.TypeOf((*ConfigurationService)(nil)).Elem()
@@ -10,7 +10,7 @@ type CoverageStoresService interface {
 	GetCoverageStores(workspaceName string) (coverageStores []*Resource, err error)
 	GetCoverageStore(workspaceName string, gridName string) (coverageStore *CoverageStore, err error)
 	CreateCoverageStore(workspaceName string, coverageStore CoverageStore) (created bool, err error)
-	UpdateCoverageStore(workspaceName string, coverageStore CoverageStore) (modified bool, err error)
+	UpdateCoverageStore(workspaceName string, coverageStore CoverageStore, fields ...string) (modified bool, err error)
 	DeleteCoverageStore(workspaceName string, coverageStore string, recurse bool) (deleted bool, err error)
 }
 
@@ -108,10 +108,16 @@ func (g *GeoServer) CreateCoverageStore(workspaceName string, coverageStore Cove
 	return
 }
 
-// UpdateCoverageStore  parital update coverage store in geoserver else return error
-func (g *GeoServer) UpdateCoverageStore(workspaceName string, coverageStore CoverageStore) (modified bool, err error) {
+// UpdateCoverageStore  parital update coverage store in geoserver else return error,
+// fields is an optional field mask, a list of coverage store json field names to send even if they are false, 0 or "" (e.g. "enabled"),
+// without fields only the fields set in the coverageStore are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateCoverageStore(workspaceName string, coverageStore CoverageStore, fields ...string) (modified bool, err error) {
 	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "coveragestores", coverageStore.Name)
-	data := CoverageStoreRequestBody{CoverageStore: &coverageStore}
+	patch, err := patchEntity(coverageStore, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"coverageStore": patch}
 	serializedData, _ := g.SerializeStruct(data)
 	httpRequest := HTTPRequest{
 		Method:   putMethod,
@@ -235,7 +235,10 @@ func (g *GeoServer) DeleteCoverage(workspaceName string, layerName string, recur
 }
 
 // UpdateCoverage updates geoserver coverage (raster layer), else returns error,
-func (g *GeoServer) UpdateCoverage(workspaceName string, coverage *Coverage) (modified bool, err error) {
+// fields is an optional field mask, a list of coverage json field names to send (e.g. "enabled"), only these fields are sent
+// even if they are false, 0 or "" and the others are left untouched on the server,
+// without fields the name, title, description, abstract, keywords, enabled and the bounding boxes set in the coverage are sent
+func (g *GeoServer) UpdateCoverage(workspaceName string, coverage *Coverage, fields ...string) (modified bool, err error) {
 
 	items := strings.Split(coverage.Store.Name, ":")
 	if len(items) != 2 {
@@ -255,7 +258,7 @@ func (g *GeoServer) UpdateCoverage(workspaceName string, coverage *Coverage) (mo
 	}
 
 	type coverageUpdateRequestBody struct {
-		Coverage CoverageUpdate `json:"coverage,omitempty"`
+		Coverage interface{} `json:"coverage,omitempty"`
 	}
 
 	data := coverageUpdateRequestBody{Coverage: CoverageUpdate{
@@ -268,6 +271,11 @@ func (g *GeoServer) UpdateCoverage(workspaceName string, coverage *Coverage) (mo
 		NativeBoundingBox: coverage.NativeBoundingBox,
 		LatLonBoundingBox: coverage.LatLonBoundingBox,
 	}}
+	if len(fields) != 0 {
+		if data.Coverage, err = maskEntity(coverage, fields); err != nil {
+			return false, err
+		}
+	}
 
 	serializedLayer, _ := g.SerializeStruct(data)
 	httpRequest := HTTPRequest{
@@ -3,6 +3,8 @@ package geoserver
 import (
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -135,6 +137,29 @@ func TestUpdateCoverage(t *testing.T) {
 
 }
 
+func TestUpdateCoveragePartial(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, putMethod, r.Method)
+		assert.Equal(t, "/rest/workspaces/sf/coveragestores/dem/coverages/sfdem", r.URL.Path)
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	coverage := &Coverage{Name: "sfdem", Title: "DEM", Store: &Resource{Name: "sf:dem"}, Srs: "EPSG:26713"}
+
+	modified, err := gsCatalog.UpdateCoverage("sf", coverage)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "sfdem", "title": "DEM"}, body["coverage"])
+
+	modified, err = gsCatalog.UpdateCoverage("sf", coverage, "enabled")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"enabled": false}, body["coverage"])
+}
+
 func TestGetCoverages(t *testing.T) {
 	test_before(t)
 
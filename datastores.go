@@ -20,6 +20,9 @@ type DatastoreService interface {
 	//CreateDatastore create a datastore under provided workspace
 	CreateDatastore(datastoreConnection DatastoreConnection, workspaceName string) (created bool, err error)
 
+	// UpdateDatastore partial update a datastore in a workspace else return error, fields is an optional field mask
+	UpdateDatastore(workspaceName string, datastoreName string, datastore Datastore, fields ...string) (modified bool, err error)
+
 	// DeleteDatastore deletes a datastore from geoserver else return error
 	DeleteDatastore(workspaceName string, datastoreName string, recurse bool) (deleted bool, err error)
 }
@@ -248,6 +251,27 @@ func (g *GeoServer) CreateDatastore(datastoreConnection DatastoreConnector, work
 
 }
 
+// UpdateDatastore partial update a datastore in a workspace else return error,
+// fields is an optional field mask, a list of datastore json field names to send even if they are false or "" (e.g. "enabled"),
+// without fields only the fields set in the datastore are sent, the others are left untouched on the server,
+// note that connectionParameters are replaced as a whole if sent
+func (g *GeoServer) UpdateDatastore(workspaceName string, datastoreName string, datastore Datastore, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "datastores", datastoreName)
+	patch, err := patchEntity(datastore, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"dataStore": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Warn(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
 // DeleteDatastore deletes a datastore from geoserver else return error
 func (g *GeoServer) DeleteDatastore(workspaceName string, datastoreName string, recurse bool) (deleted bool, err error) {
 	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "datastores", datastoreName)
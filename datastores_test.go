@@ -141,6 +141,23 @@ func (suite *GeoserverDatastoreSuite) Test07CreateDatastoreJNDI() {
 	assert.NotNil(suite.T(), err)
 }
 
+func (suite *GeoserverDatastoreSuite) Test08UpdateDatastore() {
+	_, _ = suite.CreateDatastore()
+	defer func() {
+		_, _ = suite.gsCatalog.DeleteDatastore(suite.workspaceName, suite.datastoreName, true)
+	}()
+	modified, err := suite.gsCatalog.UpdateDatastore(suite.workspaceName, suite.datastoreName, Datastore{Enabled: false}, "enabled")
+	assert.True(suite.T(), modified)
+	assert.Nil(suite.T(), err)
+	datastore, err := suite.gsCatalog.GetDatastoreDetails(suite.workspaceName, suite.datastoreName)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), datastore.Enabled)
+	assert.NotEmpty(suite.T(), datastore.ConnectionParameters.Entry)
+	modified, err = suite.gsCatalog.UpdateDatastore(suite.workspaceName, suite.datastoreName+"_dummy", Datastore{Enabled: true}, "enabled")
+	assert.False(suite.T(), modified)
+	assert.NotNil(suite.T(), err)
+}
+
 func TestGeoserverDatastoreSuite(t *testing.T) {
 	suite.Run(t, new(GeoserverDatastoreSuite))
 }
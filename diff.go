@@ -0,0 +1,512 @@
+package geoserver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+const (
+	KindFeatureType   = "featureType"
+	KindCoverageStore = "coverageStore"
+	KindCoverage      = "coverage"
+)
+
+// manifestLayerType is the type of the layers described by the manifest, the layers of the feature types
+const manifestLayerType = "VECTOR"
+
+// diffKinds is the order of the kinds in the diff report
+var diffKinds = []string{KindWorkspace, KindStyle, KindDatastore, KindFeatureType, KindCoverageStore, KindCoverage, KindLayer, KindLayerGroup, KindACLRule}
+
+// CatalogObject contains the compared fields of the catalog object
+type CatalogObject map[string]string
+
+// CatalogState is the flat description of the catalog compared by DiffCatalogs,
+// Objects maps the kind (one of Kind* constants) to the objects of the kind by the qualified name,
+// only the kinds described by both states are compared,
+// Partial state describes only the specified fields (e.g. the manifest), missing fields aren't compared
+type CatalogState struct {
+	Objects map[string]map[string]CatalogObject
+	Partial bool
+	// types maps the kind to the only object type (the "type" field) the state can describe,
+	// the objects of the other types existing in the other state only aren't compared
+	types map[string]string
+}
+
+// CatalogStateSource is the source of the catalog state, it's implemented by GeoServer and Manifest
+type CatalogStateSource interface {
+	CatalogState(workspaces ...string) (*CatalogState, error)
+}
+
+// FieldDiff is the difference of the object field
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// DiffEntry is the added, removed or changed object, Change is one of Diff* constants
+type DiffEntry struct {
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name"`
+	Change string      `json:"change"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// CatalogDiff is the structural difference between the source and the target catalogs,
+// added objects exist in the target only, removed objects exist in the source only
+type CatalogDiff struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+func newCatalogState(partial bool, kinds ...string) *CatalogState {
+	state := &CatalogState{Objects: make(map[string]map[string]CatalogObject, len(kinds)), Partial: partial}
+	for _, kind := range kinds {
+		state.Objects[kind] = map[string]CatalogObject{}
+	}
+	return state
+}
+
+func (s *CatalogState) add(kind string, name string, object CatalogObject) {
+	s.Objects[kind][name] = object
+}
+
+// describes returns true if the state can describe the object of the kind
+func (s *CatalogState) describes(kind string, object CatalogObject) bool {
+	describedType, ok := s.types[kind]
+	if !ok {
+		return true
+	}
+	objectType, ok := object["type"]
+	return !ok || objectType == describedType
+}
+
+// DiffCatalogs compares the catalogs of the sources, only the workspaces given are compared (all if none given),
+// global styles are compared only if the whole catalogs are compared
+func DiffCatalogs(source CatalogStateSource, target CatalogStateSource, workspaces ...string) (*CatalogDiff, error) {
+	sourceState, err := source.CatalogState(workspaces...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get source catalog: %v", err)
+	}
+	targetState, err := target.CatalogState(workspaces...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get target catalog: %v", err)
+	}
+	return DiffCatalogStates(sourceState, targetState), nil
+}
+
+// DiffCatalogStates compares the catalog states
+func DiffCatalogStates(source *CatalogState, target *CatalogState) *CatalogDiff {
+	partial := source.Partial || target.Partial
+	diff := &CatalogDiff{Entries: []DiffEntry{}}
+	for _, kind := range diffKinds {
+		sourceObjects, ok := source.Objects[kind]
+		if !ok {
+			continue
+		}
+		targetObjects, ok := target.Objects[kind]
+		if !ok {
+			continue
+		}
+		for _, name := range sortedObjectNames(sourceObjects, targetObjects) {
+			sourceObject, inSource := sourceObjects[name]
+			targetObject, inTarget := targetObjects[name]
+			switch {
+			case !inTarget && !target.describes(kind, sourceObject), !inSource && !source.describes(kind, targetObject):
+				continue
+			case !inTarget:
+				diff.Entries = append(diff.Entries, DiffEntry{Kind: kind, Name: name, Change: DiffRemoved})
+			case !inSource:
+				diff.Entries = append(diff.Entries, DiffEntry{Kind: kind, Name: name, Change: DiffAdded})
+			default:
+				if fields := diffObjects(sourceObject, targetObject, partial); len(fields) != 0 {
+					diff.Entries = append(diff.Entries, DiffEntry{Kind: kind, Name: name, Change: DiffChanged, Fields: fields})
+				}
+			}
+		}
+	}
+	return diff
+}
+
+func sortedObjectNames(a map[string]CatalogObject, b map[string]CatalogObject) []string {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffObjects compares the fields of the objects, if partial is true only the fields of both objects are compared
+func diffObjects(source CatalogObject, target CatalogObject, partial bool) (fields []FieldDiff) {
+	keys := make(map[string]bool, len(source)+len(target))
+	for key := range source {
+		keys[key] = true
+	}
+	for key := range target {
+		keys[key] = true
+	}
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	for _, key := range names {
+		sourceValue, inSource := source[key]
+		targetValue, inTarget := target[key]
+		if partial && (!inSource || !inTarget) {
+			continue
+		}
+		if sourceValue != targetValue {
+			fields = append(fields, FieldDiff{Field: key, Source: sourceValue, Target: targetValue})
+		}
+	}
+	return
+}
+
+// Empty returns true if the catalogs don't differ
+func (d *CatalogDiff) Empty() bool {
+	return len(d.Entries) == 0
+}
+
+// String returns the human readable report
+func (d *CatalogDiff) String() string {
+	signs := map[string]string{DiffAdded: "+", DiffRemoved: "-", DiffChanged: "~"}
+	var report strings.Builder
+	for _, e := range d.Entries {
+		fmt.Fprintf(&report, "%s %s %s\n", signs[e.Change], e.Kind, e.Name)
+		for _, f := range e.Fields {
+			fmt.Fprintf(&report, "    %s: %q -> %q\n", f.Field, f.Source, f.Target)
+		}
+	}
+	return report.String()
+}
+
+// JSON returns the json report
+func (d *CatalogDiff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// styleDigest returns the short digest of the style body used to compare the styles
+func styleDigest(body []byte) string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(string(body))))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// includeWorkspace returns true if the workspace is in the list or the list is empty
+func includeWorkspace(workspaces []string, workspaceName string) bool {
+	if len(workspaces) == 0 {
+		return true
+	}
+	for _, ws := range workspaces {
+		if ws == workspaceName {
+			return true
+		}
+	}
+	return false
+}
+
+// CatalogState describes the server catalog, only the workspaces given are described (all if none given)
+func (g *GeoServer) CatalogState(workspaces ...string) (state *CatalogState, err error) {
+	state = newCatalogState(false, diffKinds...)
+	wholeCatalog := len(workspaces) == 0
+	if wholeCatalog {
+		liveWorkspaces, err := g.GetWorkspaces()
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range liveWorkspaces {
+			workspaces = append(workspaces, ws.Name)
+		}
+		if err = g.addStylesState(state, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, ws := range workspaces {
+		if err = g.addWorkspaceState(state, ws); err != nil {
+			return nil, fmt.Errorf("can't describe workspace %s: %v", ws, err)
+		}
+	}
+	rules, err := g.GetLayersAclRules()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if wholeCatalog || includeWorkspace(workspaces, r.Workspace) {
+			ruleString, roles := r.ToStrings()
+			state.add(KindACLRule, ruleString, CatalogObject{"roles": sortedRoles(roles)})
+		}
+	}
+	return state, nil
+}
+
+func sortedRoles(roles string) string {
+	list := strings.Split(roles, ",")
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func (g *GeoServer) addStylesState(state *CatalogState, workspaceName string) error {
+	styles, err := g.GetStyles(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, s := range styles {
+		_, name := splitQualifiedName(s.Name)
+		body, err := g.GetStyleBody(workspaceName, name)
+		if err != nil {
+			return err
+		}
+		state.add(KindStyle, qualifiedStyleName(workspaceName, name), CatalogObject{"body": styleDigest(body)})
+	}
+	return nil
+}
+
+func (g *GeoServer) addWorkspaceState(state *CatalogState, workspaceName string) error {
+	workspace, err := g.GetWorkspace(workspaceName)
+	if err != nil {
+		return err
+	}
+	state.add(KindWorkspace, workspaceName, CatalogObject{"isolated": strconv.FormatBool(workspace.Isolated)})
+
+	if err = g.addStylesState(state, workspaceName); err != nil {
+		return err
+	}
+
+	datastores, err := g.GetDatastores(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, ds := range datastores {
+		datastore, err := g.GetDatastoreDetails(workspaceName, ds.Name)
+		if err != nil {
+			return err
+		}
+		object := CatalogObject{"type": datastore.Type, "enabled": strconv.FormatBool(datastore.Enabled)}
+		for _, e := range datastore.ConnectionParameters.Entry {
+			if !isPasswordParameter(e.Key) && e.Key != "namespace" {
+				object["connection."+e.Key] = e.Value
+			}
+		}
+		state.add(KindDatastore, qualifyName(workspaceName, ds.Name), object)
+
+		featureTypes, err := g.GetFeatureTypes(workspaceName, ds.Name)
+		if err != nil {
+			return err
+		}
+		for _, ft := range featureTypes {
+			featureType, err := g.GetFeatureType(workspaceName, ds.Name, ft.Name)
+			if err != nil {
+				return err
+			}
+			state.add(KindFeatureType, qualifyName(workspaceName, ft.Name), CatalogObject{
+				"store":      ds.Name,
+				"nativeName": featureType.NativeName,
+				"title":      featureType.Title,
+				"abstract":   featureType.Abstract,
+				"srs":        featureType.Srs,
+				"enabled":    strconv.FormatBool(featureType.Enabled),
+			})
+		}
+	}
+
+	coverageStores, err := g.GetCoverageStores(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, cs := range coverageStores {
+		coverageStore, err := g.GetCoverageStore(workspaceName, cs.Name)
+		if err != nil {
+			return err
+		}
+		state.add(KindCoverageStore, qualifyName(workspaceName, cs.Name), CatalogObject{
+			"type":        coverageStore.Type,
+			"url":         coverageStore.URL,
+			"description": coverageStore.Description,
+			"enabled":     strconv.FormatBool(coverageStore.Enabled),
+		})
+	}
+	coverages, err := g.GetCoverages(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, c := range coverages {
+		coverage, err := g.GetCoverage(workspaceName, c.Name)
+		if err != nil {
+			return err
+		}
+		object := CatalogObject{
+			"nativeName": coverage.NativeName,
+			"title":      coverage.Title,
+			"abstract":   coverage.Abstract,
+			"srs":        coverage.Srs,
+			"enabled":    strconv.FormatBool(coverage.Enabled),
+		}
+		if coverage.Store != nil {
+			_, object["store"] = splitQualifiedName(coverage.Store.Name)
+		}
+		state.add(KindCoverage, qualifyName(workspaceName, c.Name), object)
+	}
+
+	layers, err := g.GetLayers(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, l := range layers {
+		_, name := splitQualifiedName(l.Name)
+		layer, err := g.GetLayer(workspaceName, name)
+		if err != nil {
+			return err
+		}
+		object := CatalogObject{"type": layer.Type, "enabled": strconv.FormatBool(layer.Enabled == nil || *layer.Enabled), "defaultStyle": "", "styles": ""}
+		if layer.DefaultStyle != nil {
+			object["defaultStyle"] = layer.DefaultStyle.Name
+		}
+		if layer.Styles != nil {
+			styles := make([]string, 0, len(layer.Styles.Style))
+			for _, s := range layer.Styles.Style {
+				styles = append(styles, s.Name)
+			}
+			sort.Strings(styles)
+			object["styles"] = strings.Join(styles, ",")
+		}
+		state.add(KindLayer, qualifyName(workspaceName, name), object)
+	}
+
+	groups, err := g.GetLayerGroups(workspaceName)
+	if err != nil {
+		return err
+	}
+	for _, lg := range groups {
+		layerGroup, err := g.GetLayerGroup(workspaceName, lg.Name)
+		if err != nil {
+			return err
+		}
+		state.add(KindLayerGroup, qualifyName(workspaceName, lg.Name), layerGroupObject(workspaceName, layerGroup))
+	}
+	return nil
+}
+
+// layerGroupObject describes the layergroup, the publishables and their styles are compared in order
+func layerGroupObject(workspaceName string, layerGroup *LayerGroup) CatalogObject {
+	layers := make([]string, 0, len(layerGroup.Publishables.Published))
+	styles := make([]string, 0, len(layerGroup.Publishables.Published))
+	for i, item := range layerGroup.Publishables.Published {
+		layers = append(layers, qualifyName(workspaceName, item.Name))
+		style := ""
+		if i < len(layerGroup.Styles.Style) && layerGroup.Styles.Style[i] != nil {
+			style = layerGroup.Styles.Style[i].Name
+		}
+		styles = append(styles, style)
+	}
+	return CatalogObject{
+		"mode":     layerGroup.Mode,
+		"title":    layerGroup.Title,
+		"abstract": layerGroup.Abstract,
+		"layers":   strings.Join(layers, ","),
+		"styles":   strings.Join(styles, ","),
+	}
+}
+
+// CatalogState describes the manifest, the state is partial cause the manifest describes only the specified fields,
+// the coverages aren't described by the manifest so they aren't compared,
+// the layers are the vector ones so the raster and cascaded layers of the other state aren't compared as well
+func (m *Manifest) CatalogState(workspaces ...string) (state *CatalogState, err error) {
+	state = newCatalogState(true, KindWorkspace, KindStyle, KindDatastore, KindFeatureType, KindLayer, KindLayerGroup, KindACLRule)
+	state.types = map[string]string{KindLayer: manifestLayerType}
+	if len(workspaces) == 0 {
+		for _, s := range m.Styles {
+			body, err := m.styleBody(s)
+			if err != nil {
+				return nil, err
+			}
+			state.add(KindStyle, s.Name, CatalogObject{"body": styleDigest(body)})
+		}
+	}
+	for _, ws := range m.Workspaces {
+		if !includeWorkspace(workspaces, ws.Name) {
+			continue
+		}
+		state.add(KindWorkspace, ws.Name, CatalogObject{"isolated": strconv.FormatBool(ws.Isolated)})
+		for _, s := range ws.Styles {
+			body, err := m.styleBody(s)
+			if err != nil {
+				return nil, err
+			}
+			state.add(KindStyle, qualifyName(ws.Name, s.Name), CatalogObject{"body": styleDigest(body)})
+		}
+		for _, ds := range ws.Datastores {
+			object := CatalogObject{}
+			if ds.Enabled != nil {
+				object["enabled"] = strconv.FormatBool(*ds.Enabled)
+			}
+			for key, value := range ds.Connection {
+				if !isPasswordParameter(key) && key != "namespace" {
+					object["connection."+key] = value
+				}
+			}
+			state.add(KindDatastore, qualifyName(ws.Name, ds.Name), object)
+		}
+		for _, l := range ws.Layers {
+			featureType := CatalogObject{"store": l.Datastore, "nativeName": l.NativeName}
+			if l.NativeName == "" {
+				featureType["nativeName"] = l.Name
+			}
+			for key, value := range map[string]string{"title": l.Title, "abstract": l.Abstract, "srs": l.Srs} {
+				if value != "" {
+					featureType[key] = value
+				}
+			}
+			state.add(KindFeatureType, qualifyName(ws.Name, l.Name), featureType)
+
+			layer := CatalogObject{"type": manifestLayerType}
+			defaultStyle, styles := ws.manifestLayerStyles(l)
+			if defaultStyle != nil {
+				layer["defaultStyle"] = defaultStyle.Name
+			}
+			if styles != nil {
+				names := make([]string, 0, len(styles.Style))
+				for _, s := range styles.Style {
+					names = append(names, s.Name)
+				}
+				sort.Strings(names)
+				layer["styles"] = strings.Join(names, ",")
+			}
+			if l.Enabled != nil {
+				layer["enabled"] = strconv.FormatBool(*l.Enabled)
+			}
+			state.add(KindLayer, qualifyName(ws.Name, l.Name), layer)
+		}
+		for _, mlg := range ws.LayerGroups {
+			object := layerGroupObject(ws.Name, ws.manifestLayerGroup(mlg))
+			for _, key := range []string{"title", "abstract"} {
+				if object[key] == "" {
+					delete(object, key)
+				}
+			}
+			state.add(KindLayerGroup, qualifyName(ws.Name, mlg.Name), object)
+		}
+	}
+	for _, r := range m.ACL {
+		if len(workspaces) == 0 || includeWorkspace(workspaces, r.Workspace) {
+			ruleString, roles := r.AclRule().ToStrings()
+			state.add(KindACLRule, ruleString, CatalogObject{"roles": sortedRoles(roles)})
+		}
+	}
+	return state, nil
+}
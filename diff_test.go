@@ -0,0 +1,78 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCatalogStates(t *testing.T) {
+	source := newCatalogState(false, KindWorkspace, KindLayer, KindCoverage)
+	source.add(KindWorkspace, "ws", CatalogObject{"isolated": "false"})
+	source.add(KindLayer, "ws:roads", CatalogObject{"title": "Roads", "enabled": "true"})
+	source.add(KindLayer, "ws:rivers", CatalogObject{"title": "Rivers"})
+	source.add(KindCoverage, "ws:dem", CatalogObject{})
+
+	target := newCatalogState(true, KindWorkspace, KindLayer)
+	target.add(KindWorkspace, "ws", CatalogObject{"isolated": "false"})
+	target.add(KindLayer, "ws:roads", CatalogObject{"title": "Main roads"})
+	target.add(KindLayer, "ws:lakes", CatalogObject{})
+
+	diff := DiffCatalogStates(source, target)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []DiffEntry{
+		{Kind: KindLayer, Name: "ws:lakes", Change: DiffAdded},
+		{Kind: KindLayer, Name: "ws:rivers", Change: DiffRemoved},
+		{Kind: KindLayer, Name: "ws:roads", Change: DiffChanged, Fields: []FieldDiff{{Field: "title", Source: "Roads", Target: "Main roads"}}},
+	}, diff.Entries)
+	assert.Equal(t, "+ layer ws:lakes\n- layer ws:rivers\n~ layer ws:roads\n    title: \"Roads\" -> \"Main roads\"\n", diff.String())
+
+	data, err := diff.JSON()
+	assert.Nil(t, err)
+	var decoded CatalogDiff
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, diff.Entries, decoded.Entries)
+
+	// the partial state doesn't describe raster layers, they are compared only if it has the layer
+	target.types = map[string]string{KindLayer: "VECTOR"}
+	source.add(KindLayer, "ws:dem", CatalogObject{"type": "RASTER"})
+	source.add(KindLayer, "ws:roads", CatalogObject{"type": "VECTOR", "title": "Roads", "enabled": "true"})
+	target.add(KindLayer, "ws:hillshade", CatalogObject{"type": "VECTOR"})
+	source.add(KindLayer, "ws:hillshade", CatalogObject{"type": "RASTER"})
+	diff = DiffCatalogStates(source, target)
+	assert.Equal(t, `~ layer ws:hillshade
+    type: "RASTER" -> "VECTOR"
++ layer ws:lakes
+- layer ws:rivers
+~ layer ws:roads
+    title: "Roads" -> "Main roads"
+`, diff.String())
+
+	target.Partial = false
+	diff = DiffCatalogStates(source, target)
+	assert.Equal(t, []FieldDiff{{Field: "enabled", Source: "true", Target: ""}, {Field: "title", Source: "Roads", Target: "Main roads"},
+		{Field: "type", Source: "VECTOR", Target: ""}}, diff.Entries[3].Fields)
+}
+
+func TestDiffManifests(t *testing.T) {
+	source, err := ParseManifest([]byte(testManifest), ".")
+	assert.Nil(t, err)
+	target, err := source.Remap(SnapshotMapping{Connections: map[string]map[string]string{"parks:pg": {"host": "db.prod", "passwd": "prod"}}})
+	assert.Nil(t, err)
+	target.Workspaces[0].Layers[0].DefaultStyle = "line"
+	target.ACL = nil
+
+	diff, err := DiffCatalogs(source, target)
+	assert.Nil(t, err)
+	assert.Equal(t, `~ datastore parks:pg
+    connection.host: "localhost" -> "db.prod"
+~ layer parks:parks
+    defaultStyle: "parks:park" -> "line"
+- aclRule parks.*.r
+`, diff.String())
+
+	diff, err = DiffCatalogs(source, source, "parks")
+	assert.Nil(t, err)
+	assert.True(t, diff.Empty())
+}
@@ -0,0 +1,137 @@
+package geoserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Mutation is the write request (POST, PUT or DELETE) captured in the dry-run mode,
+// URL includes the query parameters
+type Mutation struct {
+	Method      string
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+// String returns the request line followed by the body
+func (m Mutation) String() string {
+	if len(m.Body) == 0 {
+		return m.Method + " " + m.URL
+	}
+	return fmt.Sprintf("%s %s\nContent-Type: %s\n\n%s", m.Method, m.URL, m.ContentType, m.Body)
+}
+
+// dryRun records the mutations of the client in the dry-run mode
+type dryRun struct {
+	mu        sync.Mutex
+	mutations []Mutation
+}
+
+// dryRunStatuses are the synthetic statuses of the writes which client methods don't expect to answer
+// with 201 for POST and 200 for PUT and DELETE, the key is the method and the endpoint template
+var dryRunStatuses = map[string]int{
+	"POST /rest/reload":                                         statusOk,
+	"POST /rest/reset":                                          statusOk,
+	"POST /gwc/rest/seed/{layer}.xml":                           statusOk,
+	"POST /gwc/rest/seed/{layer}.json":                          statusOk,
+	"POST /rest/security/acl/layers":                            statusOk,
+	"POST /rest/security/acl/services":                          statusOk,
+	"POST /rest/security/acl/rest":                              statusOk,
+	"POST /rest/security/roles/role/{role}/user/{user}":         statusOk,
+	"POST /rest/security/roles/role/{role}/group/{group}":       statusOk,
+	"PUT /rest/workspaces/{ws}/datastores/{store}/file.shp":     statusCreated,
+	"PUT /rest/workspaces/{ws}/datastores/{store}/url.shp":      statusCreated,
+	"PUT /rest/workspaces/{ws}/datastores/{store}/external.shp": statusCreated,
+}
+
+// SetDryRun enables or disables the dry-run mode, in the dry-run mode GET requests are sent to geoserver
+// but POST, PUT and DELETE requests are recorded instead of sending and succeed with the status expected by the client methods
+// (201 for POST and 200 for PUT and DELETE except the endpoints answering otherwise, e.g. 200 for the acl rules POST)
+// and the empty response, so the methods parsing the write response (e.g. CreateImport) fail,
+// the reads don't reflect the recorded mutations, enabling the mode clears the recorded mutations
+func (g *GeoServer) SetDryRun(enabled bool) {
+	if enabled {
+		g.dryRun = &dryRun{}
+	} else {
+		g.dryRun = nil
+	}
+}
+
+// DryRun returns true if the dry-run mode is enabled
+func (g *GeoServer) DryRun() bool {
+	return g.dryRun != nil
+}
+
+// Mutations returns the mutations recorded in the dry-run mode in the order they were issued
+func (g *GeoServer) Mutations() []Mutation {
+	if g.dryRun == nil {
+		return nil
+	}
+	g.dryRun.mu.Lock()
+	defer g.dryRun.mu.Unlock()
+	return append([]Mutation{}, g.dryRun.mutations...)
+}
+
+// ResetMutations clears the recorded mutations
+func (g *GeoServer) ResetMutations() {
+	if g.dryRun == nil {
+		return
+	}
+	g.dryRun.mu.Lock()
+	defer g.dryRun.mu.Unlock()
+	g.dryRun.mutations = nil
+}
+
+// recordMutation records the write request and returns the synthetic status of the response,
+// ok is false if the request isn't a mutation or the dry-run mode is disabled
+func (g *GeoServer) recordMutation(request *http.Request) (statusCode int, ok bool) {
+	if g.dryRun == nil || request.Method == http.MethodGet || request.Method == http.MethodHead {
+		return 0, false
+	}
+	mutation := Mutation{
+		Method:      request.Method,
+		URL:         request.URL.String(),
+		ContentType: request.Header.Get(contentTypeHeader),
+	}
+	if request.Body != nil {
+		mutation.Body, _ = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+	}
+	g.dryRun.mu.Lock()
+	g.dryRun.mutations = append(g.dryRun.mutations, mutation)
+	g.dryRun.mu.Unlock()
+
+	if !LogConsoleQuiet {
+		g.logger.Infof("%s:%s  Status=dry-run", request.Method, request.URL)
+	}
+	basePath := "/"
+	if serverURL, err := url.Parse(g.ServerURL); err == nil {
+		basePath = serverURL.Path
+	}
+	if statusCode, ok = dryRunStatuses[request.Method+" "+endpointTemplate(basePath, request.URL.Path)]; ok {
+		return statusCode, true
+	}
+	if request.Method == postMethod {
+		return statusCreated, true
+	}
+	return statusOk, true
+}
+
+// dryRunResponse returns the synthetic response of the mutation recorded by doResourceRequest
+func dryRunResponse(request *http.Request, statusCode int) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    request,
+	}
+}
@@ -0,0 +1,104 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request %s", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/rest/workspaces":
+			w.Write([]byte(`{"workspaces":{"workspace":[{"name":"ws"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	catalog.SetDryRun(true)
+	assert.True(t, catalog.DryRun())
+
+	workspaces, err := catalog.GetWorkspaces()
+	assert.Nil(t, err)
+	assert.Len(t, workspaces, 1)
+
+	created, err := catalog.CreateWorkspace("parks")
+	assert.Nil(t, err)
+	assert.True(t, created)
+	modified, err := catalog.UpdateLayer("ws", "roads", Layer{Queryable: true})
+	assert.Nil(t, err)
+	assert.True(t, modified)
+	deleted, err := catalog.DeleteWorkspace("ws", true)
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+	reloaded, err := catalog.ReloadConfigration()
+	assert.Nil(t, err)
+	assert.True(t, reloaded)
+	assert.Nil(t, catalog.WriteResource("styles/icon.svg", "image/svg+xml", strings.NewReader("<svg/>")))
+
+	mutations := catalog.Mutations()
+	assert.Len(t, mutations, 5)
+	assert.Equal(t, postMethod, mutations[0].Method)
+	assert.Equal(t, server.URL+"/rest/workspaces", mutations[0].URL)
+	assert.JSONEq(t, `{"workspace":{"name":"parks"}}`, string(mutations[0].Body))
+	assert.Equal(t, putMethod, mutations[1].Method)
+	assert.Equal(t, server.URL+"/rest/workspaces/ws/layers/roads", mutations[1].URL)
+	assert.JSONEq(t, `{"layer":{"queryable":true}}`, string(mutations[1].Body))
+	assert.Equal(t, "DELETE "+server.URL+"/rest/workspaces/ws?recurse=true", mutations[2].String())
+	assert.Equal(t, server.URL+"/rest/reload", mutations[3].URL)
+	assert.Equal(t, "PUT "+server.URL+"/rest/resource/styles/icon.svg\nContent-Type: image/svg+xml\n\n<svg/>", mutations[4].String())
+
+	catalog.ResetMutations()
+	assert.Empty(t, catalog.Mutations())
+	catalog.SetDryRun(false)
+	assert.False(t, catalog.DryRun())
+	assert.Nil(t, catalog.Mutations())
+}
+
+func TestDryRunStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request %s", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/rest/styles":
+			w.Write([]byte(`{"styles":{"style":[{"name":"line"}]}}`))
+		case "/rest/styles/line":
+			w.Write([]byte("<StyledLayerDescriptor/>\n"))
+		case "/rest/security/acl/layers":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	catalog.SetDryRun(true)
+
+	done, err := catalog.AddLayersAclRule(AclRule{Workspace: "parks", Layer: "*", Operation: AclOpRead, Roles: []string{"ROLE_PARKS"}})
+	assert.Nil(t, err)
+	assert.True(t, done)
+	done, err = catalog.AddUserRole("ROLE_PARKS", "parks")
+	assert.Nil(t, err)
+	assert.True(t, done)
+	assert.Len(t, catalog.Mutations(), 2)
+
+	catalog.ResetMutations()
+	manifest, err := ParseManifest([]byte(testManifest), ".")
+	assert.Nil(t, err)
+	plan, err := catalog.Plan(manifest)
+	assert.Nil(t, err)
+	applied, err := catalog.Apply(plan)
+	assert.Nil(t, err)
+	assert.Equal(t, len(plan.Changes), applied)
+	mutations := catalog.Mutations()
+	assert.Equal(t, "POST "+server.URL+"/rest/security/acl/layers", mutations[len(mutations)-1].Method+" "+mutations[len(mutations)-1].URL)
+}
@@ -1,8 +1,9 @@
 package geoserver
 
 type GsError struct {
-	err  string
-	dump string
+	err    string
+	dump   string
+	status int
 }
 
 func (e GsError) Error() string {
@@ -13,6 +14,11 @@ func (e GsError) Dump() string {
 	return e.dump
 }
 
+// StatusCode returns http status code of geoserver response, 0 if the request failed before the response
+func (e GsError) StatusCode() int {
+	return e.status
+}
+
 var statusErrorMapping = map[int]GsError{
 	statusNotAllowed:    {err: "Method Not Allowed"},
 	statusNotFound:      {err: "Not Found"},
@@ -20,3 +26,9 @@ var statusErrorMapping = map[int]GsError{
 	statusInternalError: {err: "Internal Server Error"},
 	statusForbidden:     {err: "Forbidden"},
 }
+
+// isNotFoundError returns true if the err is geoserver Not Found error
+func isNotFoundError(err error) bool {
+	gsErr, ok := err.(GsError)
+	return ok && gsErr.err == statusErrorMapping[statusNotFound].err
+}
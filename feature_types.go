@@ -3,6 +3,7 @@ package geoserver
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"strconv"
@@ -15,7 +16,7 @@ type CRSType struct {
 	Value string `json:"$,omitempty"`
 }
 
-// UnmarshalJSON custom deserialization to handle published layers of group
+// UnmarshalJSON custom crs deserialization, the crs is either a plain crs code string or an object with the class and the wkt
 func (u *CRSType) UnmarshalJSON(data []byte) error {
 	var raw interface{}
 	err := json.Unmarshal(data, &raw)
@@ -24,9 +25,15 @@ func (u *CRSType) UnmarshalJSON(data []byte) error {
 	}
 	switch raw := raw.(type) {
 	case map[string]interface{}:
-		*u = CRSType{Class: raw["@class"].(string), Value: raw["$"].(string)}
-	case interface{}:
-		*u = CRSType{Class: "string", Value: string(data)}
+		class, _ := raw["@class"].(string)
+		value, _ := raw["$"].(string)
+		*u = CRSType{Class: class, Value: value}
+	case string:
+		*u = CRSType{Class: "string", Value: raw}
+	case nil:
+		*u = CRSType{}
+	default:
+		return fmt.Errorf("unexpected crs %s", data)
 	}
 	return nil
 }
@@ -49,9 +56,29 @@ func (u *CRSType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// MarshalXML custom crs serialization, a plain crs code is written without the class attribute
+func (u *CRSType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if u.Class != "" && u.Class != "string" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "class"}, Value: u.Class})
+	}
+	return e.EncodeElement(u.Value, start)
+}
+
+// UnmarshalXML custom crs deserialization, a crs without the class attribute is a plain crs code
+func (u *CRSType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*u = CRSType{Class: "string"}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "class" {
+			u.Class = attr.Value
+		}
+	}
+	return d.DecodeElement(&u.Value, &start)
+}
+
 // FeatureTypeService define all geoserver featuretype operations
 type FeatureTypeService interface {
 	GetFeatureTypes(workspaceName string, datastoreName string) (featureTypes []*Resource, err error)
+	IterateFeatureTypes(workspaceName string, datastoreName string, options IteratorOptions) (*ResourceIterator, error)
 	GetFeatureType(workspaceName string, datastoreName string, featureTypeName string) (featureType *FeatureType, err error)
 	DeleteFeatureType(workspaceName string, datastoreName string, featureTypeName string, recurse bool) (deleted bool, err error)
 }
@@ -64,10 +91,10 @@ type Entry struct {
 
 // BoundingBox is geoserver Bounding Box for FeatureType
 type BoundingBox struct {
-	Minx float64 `json:"minx,omitempty"`
-	Maxx float64 `json:"maxx,omitempty"`
-	Miny float64 `json:"miny,omitempty"`
-	Maxy float64 `json:"maxy,omitempty"`
+	Minx float64 `json:"minx,omitempty" xml:"minx"`
+	Maxx float64 `json:"maxx,omitempty" xml:"maxx"`
+	Miny float64 `json:"miny,omitempty" xml:"miny"`
+	Maxy float64 `json:"maxy,omitempty" xml:"maxy"`
 }
 
 // Metadata is the geoserver Metadata
@@ -75,6 +102,54 @@ type Metadata struct {
 	Entry []*Entry `json:"entry,omitempty"`
 }
 
+// UnmarshalJSON custom deserialization to handle a single metadata entry
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Entry json.RawMessage `json:"entry,omitempty"`
+	}
+	*m = Metadata{}
+	if isEmptyJSONList(data) {
+		// geoserver returns "" for the empty metadata
+		return nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Entry) == 0 {
+		return nil
+	}
+	if raw.Entry[0] == '{' {
+		entry := &Entry{}
+		if err := json.Unmarshal(raw.Entry, entry); err != nil {
+			return err
+		}
+		m.Entry = []*Entry{entry}
+		return nil
+	}
+	return json.Unmarshal(raw.Entry, &m.Entry)
+}
+
+// Get returns the value of the metadata entry with key
+func (m Metadata) Get(key string) (value string, ok bool) {
+	for _, e := range m.Entry {
+		if e != nil && e.Key == key {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the metadata entry with key, the entry is added if it doesn't exist
+func (m *Metadata) Set(key string, value string) {
+	for _, e := range m.Entry {
+		if e != nil && e.Key == key {
+			e.Value = value
+			return
+		}
+	}
+	m.Entry = append(m.Entry, &Entry{Key: key, Value: value})
+}
+
 // Keywords is the geoserver Keywords
 type Keywords struct {
 	String []string `json:"string,omitempty"`
@@ -88,7 +163,7 @@ type ResponseSRS struct {
 // NativeBoundingBox is geoserver NativeBoundingBox for FeatureType
 type NativeBoundingBox struct {
 	BoundingBox
-	Crs *CRSType `json:"crs,omitempty"`
+	Crs *CRSType `json:"crs,omitempty" xml:"crs,omitempty"`
 }
 
 // LatLonBoundingBox is geoserver LatLonBoundingBox for FeatureType
@@ -99,9 +174,9 @@ type LatLonBoundingBox struct {
 
 // MetadataLink is geoserver metadata link
 type MetadataLink struct {
-	Type         string `json:"type,omitempty"`
-	MetadataType string `json:"metadataType,omitempty"`
-	Content      string `json:"content,omitempty"`
+	Type         string `json:"type,omitempty" xml:"type,omitempty"`
+	MetadataType string `json:"metadataType,omitempty" xml:"metadataType,omitempty"`
+	Content      string `json:"content,omitempty" xml:"content,omitempty"`
 }
 
 // MetadataLinks is the geoserver metadata links
@@ -274,8 +349,11 @@ func (g *GeoServer) GetFeatureType(workspaceName string, datastoreName string, f
 // featureTypeName is a featureType name or empty (featureType.Name value will be used)
 // recalculate can be nil, or an array of recalculate options: "nativebbox", "latlonbbox"
 // an empty recalculate array cause to avoid all recalculation on large dataset
+// fields is an optional field mask, a list of featureType json field names to send (e.g. "enabled"), only these fields are sent
+// even if they are false, 0 or "" and the others are left untouched on the server,
+// without fields the name, title, abstract, keywords, enabled, srs and the bounding boxes set in the featureType are sent
 // see https://docs.geoserver.org/latest/en/api/#1.0.0/featuretypes.yaml
-func (g *GeoServer) UpdateFeatureType(workspaceName string, featureType *FeatureType, featureTypeName string, recalculate []string) (modified bool, err error) {
+func (g *GeoServer) UpdateFeatureType(workspaceName string, featureType *FeatureType, featureTypeName string, recalculate []string, fields ...string) (modified bool, err error) {
 
 	items := strings.Split(featureType.Store.Name, ":")
 	if len(items) != 2 {
@@ -307,7 +385,7 @@ func (g *GeoServer) UpdateFeatureType(workspaceName string, featureType *Feature
 	}
 
 	type featureTypeUpdateRequestBody struct {
-		FeatureType FeatureTypeUpdate `json:"featureType,omitempty"`
+		FeatureType interface{} `json:"featureType,omitempty"`
 	}
 
 	data := featureTypeUpdateRequestBody{FeatureType: FeatureTypeUpdate{
@@ -320,6 +398,11 @@ func (g *GeoServer) UpdateFeatureType(workspaceName string, featureType *Feature
 		LatLonBoundingBox: featureType.LatLonBoundingBox,
 		Srs:               featureType.Srs,
 	}}
+	if len(fields) != 0 {
+		if data.FeatureType, err = maskEntity(featureType, fields); err != nil {
+			return false, err
+		}
+	}
 
 	serializedLayer, _ := g.SerializeStruct(data)
 	httpRequest := HTTPRequest{
@@ -2,6 +2,8 @@ package geoserver
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -246,6 +248,33 @@ func TestCRSTypeMarshalJSON(t *testing.T) {
 
 }
 
+func TestUpdateFeatureTypePartial(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, putMethod, r.Method)
+		assert.Equal(t, "/rest/workspaces/ws/datastores/pg/featuretypes/roads", r.URL.Path)
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	featureType := &FeatureType{Name: "roads", Store: &Resource{Name: "ws:pg"}, CqlFilter: "type = 'primary'", MaxFeatures: 100}
+
+	modified, err := gsCatalog.UpdateFeatureType("ws", featureType, "", nil)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "roads"}, body["featureType"])
+
+	modified, err = gsCatalog.UpdateFeatureType("ws", featureType, "", nil, "enabled", "cqlFilter")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"enabled": false, "cqlFilter": "type = 'primary'"}, body["featureType"])
+
+	modified, err = gsCatalog.UpdateFeatureType("ws", featureType, "", nil, "dummy")
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+}
+
 func TestGeoserverImplementFeatureTypeService(t *testing.T) {
 	gsCatalog := reflect.TypeOf(&GeoServer{})
 	FeatureTypeServiceType := reflect.TypeOf((*FeatureTypeService)(nil)).Elem()
@@ -18,15 +18,19 @@ type GeoServer struct {
 	Password      string `yaml:"password"`
 	HttpClient    *http.Client
 	logger        *logrus.Logger
+	cache         *ReadCache
+	dryRun        *dryRun
 }
 
 var LogFile *os.File
 var LogConsoleQuiet = false
 var LogRawData = false
 
-// LoadConfig load geoserver config from yaml file
+// LoadConfig load geoserver config from yaml file, see LoadProfile for the configuration with profiles
 func (g *GeoServer) LoadConfig(configFile string) (geoserver *GeoServer, err error) {
-
+	if g.logger == nil {
+		g.logger = GetLogger()
+	}
 	yamlFile, err := ioutil.ReadFile(configFile)
 	if err != nil {
 		g.logger.Errorf("yamlFile.Get err   %v ", err)
@@ -37,7 +41,9 @@ func (g *GeoServer) LoadConfig(configFile string) (geoserver *GeoServer, err err
 		g.logger.Errorf("Unmarshal: %v", err)
 		return
 	}
-	g.logger = GetLogger()
+	if g.HttpClient == nil {
+		g.HttpClient = &http.Client{Transport: &http.Transport{DisableCompression: true, ResponseHeaderTimeout: defaultResponseHeaderTimeout}}
+	}
 	geoserver = g
 	return
 }
@@ -158,6 +158,36 @@ func (g GeoServer) GetGwcLayer(workspaceName string, layerName string) (layer Gw
 	return
 }
 
+// GetGwcGridsets returns the names of the gridsets defined in GeoWebCache
+func (g GeoServer) GetGwcGridsets() (gridsets []string, err error) {
+
+	targetURL := g.ParseURL("gwc", "rest", "gridsets")
+
+	httpRequest := HTTPRequest{
+		Method: getMethod,
+		Accept: xmlType,
+		URL:    targetURL,
+		Query:  nil,
+	}
+
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk {
+		g.logger.Error(string(response))
+		err = g.GetError(responseCode, response)
+		return
+	}
+
+	var gridsetsResponse struct {
+		Names []string `xml:"gridSet>name"`
+	}
+	err = xml.Unmarshal(response, &gridsetsResponse)
+	if err != nil {
+		err = fmt.Errorf("wrong answer, error unmarshalling XML: %v\n", err)
+		return
+	}
+	return gridsetsResponse.Names, nil
+}
+
 // UpdateGwcLayer create or update the layer caching configuration for GeoWebcache
 func (g GeoServer) UpdateGwcLayer(layer GwcLayer) (err error) {
 
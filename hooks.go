@@ -0,0 +1,225 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes the request sent to geoserver,
+// Endpoint is the template of the request path, e.g. /rest/workspaces/{ws}/layers/{layer},
+// StatusCode is 0 if the request failed with Err, Duration is the time up to the response headers including the retries
+type RequestInfo struct {
+	Method     string
+	Endpoint   string
+	URL        string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// RequestHook is called before the request is sent, the hook may add the headers to the request,
+// the returned function (if not nil) is called when the response is received
+type RequestHook func(request *http.Request, endpoint string) func(info RequestInfo)
+
+// hookTransport calls the hooks around the requests of the client
+type hookTransport struct {
+	next     http.RoundTripper
+	basePath string
+	hooks    []RequestHook
+}
+
+// Use adds the hooks called for every request sent by the client (the reads served by the cache aren't sent),
+// the http client is copied so the hooks don't affect the other users of the client,
+// the hooks are dropped if the http client is replaced later (e.g. by LoadConfig)
+func (g *GeoServer) Use(hooks ...RequestHook) {
+	client := http.Client{}
+	if g.HttpClient != nil {
+		client = *g.HttpClient
+	}
+	transport := &hookTransport{next: client.Transport, hooks: hooks}
+	if current, ok := client.Transport.(*hookTransport); ok {
+		transport.next = current.next
+		transport.hooks = append(append([]RequestHook{}, current.hooks...), hooks...)
+	}
+	if transport.next == nil {
+		transport.next = http.DefaultTransport
+	}
+	if serverURL, err := url.Parse(g.ServerURL); err == nil {
+		transport.basePath = serverURL.Path
+	}
+	client.Transport = transport
+	g.HttpClient = &client
+}
+
+func (t *hookTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	endpoint := endpointTemplate(t.basePath, request.URL.Path)
+	finish := make([]func(RequestInfo), 0, len(t.hooks))
+	for _, hook := range t.hooks {
+		if f := hook(request, endpoint); f != nil {
+			finish = append(finish, f)
+		}
+	}
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	info := RequestInfo{
+		Method:   request.Method,
+		Endpoint: endpoint,
+		URL:      request.URL.String(),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if response != nil {
+		info.StatusCode = response.StatusCode
+	}
+	for i := len(finish) - 1; i >= 0; i-- {
+		finish[i](info)
+	}
+	return response, err
+}
+
+// endpointPlaceholders are the placeholders of the names following the rest api collections
+var endpointPlaceholders = map[string]string{
+	"workspaces":     "{ws}",
+	"namespaces":     "{ns}",
+	"datastores":     "{store}",
+	"coveragestores": "{store}",
+	"wmsstores":      "{store}",
+	"wmtsstores":     "{store}",
+	"featuretypes":   "{featuretype}",
+	"coverages":      "{coverage}",
+	"layers":         "{layer}",
+	"wmslayers":      "{layer}",
+	"seed":           "{layer}",
+	"layergroups":    "{layergroup}",
+	"styles":         "{style}",
+	"templates":      "{template}",
+	"services":       "{service}",
+	"service":        "{service}",
+	"user":           "{user}",
+	"group":          "{group}",
+	"role":           "{role}",
+	"imports":        "{import}",
+	"tasks":          "{task}",
+	"blobstores":     "{blobstore}",
+	"gridsets":       "{gridset}",
+}
+
+// ogcServices are the ogc services available per workspace, e.g. /geoserver/{ws}/wms
+var ogcServices = map[string]bool{"wms": true, "wfs": true, "wcs": true, "wmts": true, "wps": true, "ows": true}
+
+// endpointTemplate replaces the names of the path by the placeholders, basePath is the path of geoserver url (e.g. /geoserver/),
+// the format extension of the name is kept, e.g. /rest/workspaces/{ws}/styles/{style}.sld
+func endpointTemplate(basePath string, requestPath string) string {
+	if !strings.HasPrefix(requestPath, basePath) {
+		return requestPath
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(requestPath, basePath), "/"), "/")
+	if len(segments) == 2 && ogcServices[strings.ToLower(segments[1])] {
+		segments[0] = "{ws}"
+	}
+	rest := false
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if !rest {
+			rest = segment == "rest"
+			continue
+		}
+		switch segment {
+		case "resource":
+			// the resource path is arbitrary
+			if i+1 < len(segments) {
+				segments = append(segments[:i+1], "{path}")
+			}
+			i = len(segments)
+			continue
+		case "acl":
+			// the acl rules are grouped by the category, e.g. /rest/security/acl/layers/{rule}
+			if i+2 < len(segments) {
+				segments[i+2] = "{rule}"
+			}
+			i += 2
+			continue
+		}
+		placeholder, ok := endpointPlaceholders[segment]
+		if ok && i+1 < len(segments) {
+			i++
+			segments[i] = placeholder + formatExt(segments[i])
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// formatExt returns the format extension of the name, "" if the name has no known extension
+func formatExt(name string) string {
+	switch ext := path.Ext(name); ext {
+	case ".json", ".xml", ".html", ".sld", ".zip":
+		return ext
+	}
+	return ""
+}
+
+// PrometheusLabels are the label names of the observations reported by PrometheusHook
+var PrometheusLabels = []string{"method", "endpoint", "status"}
+
+// PrometheusHook reports the request durations in seconds with the label values method, endpoint and status
+// (the status code or "error" if the request failed), the library doesn't depend on prometheus client,
+// observe is usually the observation of the histogram vector:
+//
+//	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "geoserver_request_duration_seconds"}, geoserver.PrometheusLabels)
+//	prometheus.MustRegister(histogram)
+//	gsCatalog.Use(geoserver.PrometheusHook(func(labelValues []string, seconds float64) {
+//		histogram.WithLabelValues(labelValues...).Observe(seconds)
+//	}))
+func PrometheusHook(observe func(labelValues []string, seconds float64)) RequestHook {
+	return func(request *http.Request, endpoint string) func(RequestInfo) {
+		return func(info RequestInfo) {
+			status := "error"
+			if info.Err == nil {
+				status = strconv.Itoa(info.StatusCode)
+			}
+			observe([]string{info.Method, info.Endpoint, status}, info.Duration.Seconds())
+		}
+	}
+}
+
+// TracingHook reports every request as the span, the library doesn't depend on opentelemetry,
+// start starts the span with the name (e.g. "GET /rest/workspaces/{ws}") and the attributes of the http client semantic conventions,
+// it may inject the trace context to the request headers and returns the function ending the span,
+// e.g. for the opentelemetry tracer:
+//
+//	gsCatalog.Use(geoserver.TracingHook(func(request *http.Request, name string, attributes map[string]string) func(int, error) {
+//		ctx, span := tracer.Start(request.Context(), name, trace.WithSpanKind(trace.SpanKindClient))
+//		for key, value := range attributes {
+//			span.SetAttributes(attribute.String(key, value))
+//		}
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+//		return func(statusCode int, err error) {
+//			span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+//			if err != nil || statusCode >= 500 {
+//				span.SetStatus(codes.Error, http.StatusText(statusCode))
+//			}
+//			span.End()
+//		}
+//	}))
+func TracingHook(start func(request *http.Request, name string, attributes map[string]string) func(statusCode int, err error)) RequestHook {
+	return func(request *http.Request, endpoint string) func(RequestInfo) {
+		attributes := map[string]string{
+			"http.request.method": request.Method,
+			"http.route":          endpoint,
+			"url.full":            request.URL.String(),
+			"server.address":      request.URL.Hostname(),
+		}
+		end := start(request, request.Method+" "+endpoint, attributes)
+		if end == nil {
+			return nil
+		}
+		return func(info RequestInfo) {
+			end(info.StatusCode, info.Err)
+		}
+	}
+}
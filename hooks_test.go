@@ -0,0 +1,75 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "trace", r.Header.Get("traceparent"))
+		switch r.URL.Path {
+		case "/rest/workspaces/ws/layers/roads":
+			w.Write([]byte(`{"layer":{"name":"roads"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	client := catalog.HttpClient
+
+	var observed [][]string
+	var spans []string
+	catalog.Use(PrometheusHook(func(labelValues []string, seconds float64) {
+		assert.True(t, seconds > 0)
+		observed = append(observed, labelValues)
+	}))
+	catalog.Use(TracingHook(func(request *http.Request, name string, attributes map[string]string) func(int, error) {
+		request.Header.Set("traceparent", "trace")
+		assert.Equal(t, name, attributes["http.request.method"]+" "+attributes["http.route"])
+		return func(statusCode int, err error) {
+			spans = append(spans, name)
+		}
+	}))
+	assert.NotEqual(t, client, catalog.HttpClient)
+	_, hooked := client.Transport.(*hookTransport)
+	assert.False(t, hooked)
+
+	_, err := catalog.GetLayer("ws", "roads")
+	assert.Nil(t, err)
+	_, err = catalog.DeleteStyle("ws", "line", true)
+	assert.NotNil(t, err)
+	assert.Equal(t, [][]string{
+		{"GET", "/rest/workspaces/{ws}/layers/{layer}", "200"},
+		{"DELETE", "/rest/workspaces/{ws}/styles/{style}", "404"},
+	}, observed)
+	assert.Equal(t, []string{"GET /rest/workspaces/{ws}/layers/{layer}", "DELETE /rest/workspaces/{ws}/styles/{style}"}, spans)
+
+	server.Close()
+	_, err = catalog.GetLayer("ws", "roads")
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"GET", "/rest/workspaces/{ws}/layers/{layer}", "error"}, observed[2])
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	for requestPath, endpoint := range map[string]string{
+		"/geoserver/rest/workspaces":                                          "/rest/workspaces",
+		"/geoserver/rest/workspaces/ws/datastores/pg/featuretypes/roads.json": "/rest/workspaces/{ws}/datastores/{store}/featuretypes/{featuretype}.json",
+		"/geoserver/rest/workspaces/ws/datastores/pg/file.shp":                "/rest/workspaces/{ws}/datastores/{store}/file.shp",
+		"/geoserver/rest/layers/ws:roads.v2/styles":                           "/rest/layers/{layer}/styles",
+		"/geoserver/rest/security/usergroup/service/default/user/admin":       "/rest/security/usergroup/service/{service}/user/{user}",
+		"/geoserver/rest/security/roles/role/ADMIN/user/admin":                "/rest/security/roles/role/{role}/user/{user}",
+		"/geoserver/rest/security/acl/layers/ws.*.r":                          "/rest/security/acl/layers/{rule}",
+		"/geoserver/rest/resource/styles/icons/a.png":                         "/rest/resource/{path}",
+		"/geoserver/gwc/rest/seed/ws:roads.json":                              "/gwc/rest/seed/{layer}.json",
+		"/geoserver/ws/wms":                                                   "/{ws}/wms",
+		"/geoserver/wms":                                                      "/wms",
+		"/other/wmts":                                                         "/other/wmts",
+	} {
+		assert.Equal(t, endpoint, endpointTemplate("/geoserver/", requestPath), requestPath)
+	}
+}
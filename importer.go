@@ -0,0 +1,466 @@
+package geoserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ImportStatePending       = "PENDING"
+	ImportStateInit          = "INIT"
+	ImportStateInitError     = "INIT_ERROR"
+	ImportStateReady         = "READY"
+	ImportStateRunning       = "RUNNING"
+	ImportStateIncomplete    = "INCOMPLETE"
+	ImportStateComplete      = "COMPLETE"
+	ImportStateCompleteError = "COMPLETE_ERROR"
+)
+
+const (
+	ImportTaskStateNoCRS     = "NO_CRS"
+	ImportTaskStateNoBounds  = "NO_BOUNDS"
+	ImportTaskStateNoFormat  = "NO_FORMAT"
+	ImportTaskStateBadFormat = "BAD_FORMAT"
+	ImportTaskStateError     = "ERROR"
+	ImportTaskStateCanceled  = "CANCELED"
+)
+
+const (
+	ImportUpdateModeCreate  = "CREATE"  //create a new layer, the name is changed if the layer already exists
+	ImportUpdateModeReplace = "REPLACE" //replace the data of the existing layer
+	ImportUpdateModeAppend  = "APPEND"  //append the data to the existing layer
+)
+
+const (
+	ImportDataFile      = "file"
+	ImportDataDirectory = "directory"
+	ImportDataDatabase  = "database"
+	ImportDataRemote    = "remote"
+)
+
+// ImporterService define geoserver importer extension operations
+type ImporterService interface {
+	CreateImport(importContext ImportContext, async bool) (created *ImportContext, err error)
+	GetImport(importID int) (importContext *ImportContext, err error)
+	DeleteImport(importID int) (deleted bool, err error)
+	UploadImportFile(importID int, fileURI string) (tasks []*ImportTask, err error)
+	AddImportURL(importID int, dataURL string) (tasks []*ImportTask, err error)
+	GetImportTasks(importID int) (tasks []*ImportTask, err error)
+	GetImportTask(importID int, taskID int) (task *ImportTask, err error)
+	UpdateImportTask(importID int, taskID int, task ImportTask, fields ...string) (modified bool, err error)
+	UpdateImportTaskLayer(importID int, taskID int, layer ImportLayer, fields ...string) (modified bool, err error)
+	GetImportTransforms(importID int, taskID int) (transforms []*ImportTransform, err error)
+	AddImportTransform(importID int, taskID int, transform ImportTransform) (added bool, err error)
+	GetImportTaskProgress(importID int, taskID int) (progress *ImportProgress, err error)
+	RunImport(importID int, async bool) (started bool, err error)
+	WaitImport(importID int, pollInterval time.Duration, timeout time.Duration) (importContext *ImportContext, err error)
+}
+
+// ImportContext is geoserver importer import, ID and State are set by the server
+type ImportContext struct {
+	ID              int                    `json:"id,omitempty"`
+	Href            string                 `json:"href,omitempty"`
+	State           string                 `json:"state,omitempty"`
+	Archive         *bool                  `json:"archive,omitempty"`
+	TargetWorkspace *ImportTargetWorkspace `json:"targetWorkspace,omitempty"`
+	TargetStore     *ImportTargetStore     `json:"targetStore,omitempty"`
+	Data            *ImportData            `json:"data,omitempty"`
+	Tasks           []*ImportTask          `json:"tasks,omitempty"`
+}
+
+// ImportTargetWorkspace is the workspace the data is imported to
+type ImportTargetWorkspace struct {
+	Workspace *Resource `json:"workspace,omitempty"`
+}
+
+// ImportTargetStore is the store the data is imported to, if not set the importer creates a new store
+type ImportTargetStore struct {
+	Href          string    `json:"href,omitempty"`
+	DataStore     *Resource `json:"dataStore,omitempty"`
+	CoverageStore *Resource `json:"coverageStore,omitempty"`
+}
+
+// ImportData is the source of the import data, Type is one of ImportData* constants,
+// File is used for the file source, Location for the directory and remote sources,
+// Parameters are the connection parameters of the database source
+type ImportData struct {
+	Type       string            `json:"type,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	File       string            `json:"file,omitempty"`
+	Location   string            `json:"location,omitempty"`
+	Username   string            `json:"username,omitempty"`
+	Password   string            `json:"password,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// ImportTask is a single unit of the import, usually a file or a database table,
+// UpdateMode is one of ImportUpdateMode* constants
+type ImportTask struct {
+	ID             int                   `json:"id,omitempty"`
+	Href           string                `json:"href,omitempty"`
+	State          string                `json:"state,omitempty"`
+	UpdateMode     string                `json:"updateMode,omitempty"`
+	Data           *ImportData           `json:"data,omitempty"`
+	Target         *ImportTargetStore    `json:"target,omitempty"`
+	Layer          *ImportLayer          `json:"layer,omitempty"`
+	TransformChain *ImportTransformChain `json:"transformChain,omitempty"`
+	ErrorMessage   string                `json:"errorMessage,omitempty"`
+	Messages       []ImportMessage       `json:"messages,omitempty"`
+}
+
+// ImportLayer is the layer created by the import task
+type ImportLayer struct {
+	Name         string    `json:"name,omitempty"`
+	Href         string    `json:"href,omitempty"`
+	OriginalName string    `json:"originalName,omitempty"`
+	NativeName   string    `json:"nativeName,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Abstract     string    `json:"abstract,omitempty"`
+	Srs          string    `json:"srs,omitempty"`
+	Style        *Resource `json:"style,omitempty"`
+}
+
+// ImportTransformChain is the list of transforms applied to the task data
+type ImportTransformChain struct {
+	Type       string             `json:"type,omitempty"`
+	Transforms []*ImportTransform `json:"transforms,omitempty"`
+}
+
+// ImportTransform is the transformation of the task data, the fields used depend on the Type,
+// see NewDateFormatTransform and NewReprojectTransform
+type ImportTransform struct {
+	Type   string `json:"type,omitempty"`
+	Href   string `json:"href,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Format string `json:"format,omitempty"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// ImportMessage is the importer message about the task, Level is a java logging level like SEVERE, WARNING, INFO
+type ImportMessage struct {
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImportProgress is the progress of the running task, Progress and Total are the processed and the total number of features
+type ImportProgress struct {
+	Progress int    `json:"progress,omitempty"`
+	Total    int    `json:"total,omitempty"`
+	State    string `json:"state,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// ImportTaskError is the failure of the single import task
+type ImportTaskError struct {
+	TaskID  int
+	State   string
+	Message string
+}
+
+// ImportError is returned when the import or some of its tasks failed
+type ImportError struct {
+	ImportID int
+	State    string
+	Tasks    []ImportTaskError
+}
+
+func (e ImportError) Error() string {
+	messages := make([]string, 0, len(e.Tasks))
+	for _, t := range e.Tasks {
+		messages = append(messages, fmt.Sprintf("task %d %s: %s", t.TaskID, t.State, t.Message))
+	}
+	return fmt.Sprintf("import %d %s: %s", e.ImportID, e.State, strings.Join(messages, "; "))
+}
+
+// NewDateFormatTransform returns the transform converting the string field to date using java date format like yyyyMMdd,
+// if format is empty the importer guesses it
+func NewDateFormatTransform(field string, format string) ImportTransform {
+	return ImportTransform{Type: "DateFormatTransform", Field: field, Format: format}
+}
+
+// NewReprojectTransform returns the transform reprojecting the data to the target srs like EPSG:4326
+func NewReprojectTransform(target string) ImportTransform {
+	return ImportTransform{Type: "ReprojectTransform", Target: target}
+}
+
+// NewImportContext returns the import targeting the workspace and the existing datastore,
+// if datastoreName is "" the importer creates a new store for the data
+func NewImportContext(workspaceName string, datastoreName string) ImportContext {
+	importContext := ImportContext{TargetWorkspace: &ImportTargetWorkspace{Workspace: &Resource{Name: workspaceName}}}
+	if datastoreName != "" {
+		importContext.TargetStore = &ImportTargetStore{DataStore: &Resource{Name: datastoreName}}
+	}
+	return importContext
+}
+
+// importURL returns the importer url of the import, task and the rest parts
+func (g *GeoServer) importURL(importID int, parts ...string) string {
+	return g.ParseURL(append([]string{"rest", "imports", strconv.Itoa(importID)}, parts...)...)
+}
+
+// importTaskURL returns the importer url of the task and the rest parts
+func (g *GeoServer) importTaskURL(importID int, taskID int, parts ...string) string {
+	return g.importURL(importID, append([]string{"tasks", strconv.Itoa(taskID)}, parts...)...)
+}
+
+// CreateImport creates the import context, if importContext.Data is set the tasks are created from it,
+// async defines whether the data source is scanned in background, the created import is returned
+func (g *GeoServer) CreateImport(importContext ImportContext, async bool) (created *ImportContext, err error) {
+	serializedImport, _ := g.SerializeStruct(map[string]interface{}{"import": importContext})
+	httpRequest := HTTPRequest{
+		Method:   postMethod,
+		Accept:   jsonType,
+		Data:     bytes.NewBuffer(serializedImport),
+		DataType: jsonType,
+		URL:      g.ParseURL("rest", "imports"),
+		Query:    map[string]string{"async": strconv.FormatBool(async)},
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusCreated {
+		g.logger.Error(string(response))
+		return nil, g.GetError(responseCode, response)
+	}
+	var importResponse struct {
+		Import *ImportContext `json:"import"`
+	}
+	if err = g.DeSerializeJSON(response, &importResponse); err != nil {
+		return nil, err
+	}
+	return importResponse.Import, nil
+}
+
+// GetImport returns the import context with its tasks,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetImport(importID int) (importContext *ImportContext, err error) {
+	var importResponse struct {
+		Import *ImportContext `json:"import"`
+	}
+	if err = g.requestResource(g.importURL(importID), &importResponse); err != nil {
+		return nil, err
+	}
+	return importResponse.Import, nil
+}
+
+// DeleteImport deletes the import context,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteImport(importID int) (deleted bool, err error) {
+	httpRequest := HTTPRequest{
+		Method: deleteMethod,
+		Accept: jsonType,
+		URL:    g.importURL(importID),
+		Query:  nil,
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk && responseCode != statusNoContent {
+		g.logger.Error(string(response))
+		return false, g.GetError(responseCode, response)
+	}
+	return true, nil
+}
+
+// UploadImportFile uploads the file (e.g. zipped shapefile, geotiff, csv) to the import, the file is streamed to the server,
+// the tasks created for the file are returned
+func (g *GeoServer) UploadImportFile(importID int, fileURI string) (tasks []*ImportTask, err error) {
+	file, err := os.Open(fileURI)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	httpRequest := HTTPRequest{
+		Method:   putMethod,
+		Accept:   jsonType,
+		Data:     file,
+		DataType: "application/octet-stream",
+		URL:      g.importURL(importID, "tasks", filepath.Base(fileURI)),
+		Query:    nil,
+	}
+	return g.createImportTasks(httpRequest)
+}
+
+// AddImportURL adds the data located on the server (e.g. file:/data/shapes/roads.shp or a directory) to the import,
+// the tasks created for the data are returned
+func (g *GeoServer) AddImportURL(importID int, dataURL string) (tasks []*ImportTask, err error) {
+	httpRequest := HTTPRequest{
+		Method:   postMethod,
+		Accept:   jsonType,
+		Data:     strings.NewReader(url.Values{"url": {dataURL}}.Encode()),
+		DataType: "application/x-www-form-urlencoded",
+		URL:      g.importURL(importID, "tasks"),
+		Query:    nil,
+	}
+	return g.createImportTasks(httpRequest)
+}
+
+// createImportTasks performs the request creating tasks, importer responds with a single task or a tasks list
+func (g *GeoServer) createImportTasks(httpRequest HTTPRequest) (tasks []*ImportTask, err error) {
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusCreated && responseCode != statusOk {
+		g.logger.Error(string(response))
+		return nil, g.GetError(responseCode, response)
+	}
+	var tasksResponse struct {
+		Task  *ImportTask   `json:"task"`
+		Tasks []*ImportTask `json:"tasks"`
+	}
+	if err = g.DeSerializeJSON(response, &tasksResponse); err != nil {
+		return nil, err
+	}
+	if tasksResponse.Task != nil {
+		return []*ImportTask{tasksResponse.Task}, nil
+	}
+	return tasksResponse.Tasks, nil
+}
+
+// GetImportTasks returns the tasks of the import,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetImportTasks(importID int) (tasks []*ImportTask, err error) {
+	var tasksResponse struct {
+		Tasks []*ImportTask `json:"tasks"`
+	}
+	if err = g.requestResource(g.importURL(importID, "tasks"), &tasksResponse); err != nil {
+		return nil, err
+	}
+	return tasksResponse.Tasks, nil
+}
+
+// GetImportTask returns the task of the import,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetImportTask(importID int, taskID int) (task *ImportTask, err error) {
+	var taskResponse struct {
+		Task *ImportTask `json:"task"`
+	}
+	if err = g.requestResource(g.importTaskURL(importID, taskID), &taskResponse); err != nil {
+		return nil, err
+	}
+	return taskResponse.Task, nil
+}
+
+// UpdateImportTask partial update the import task (e.g. UpdateMode or Target) else return error,
+// fields is an optional field mask, a list of task json field names to send even if they are zero or nil
+func (g *GeoServer) UpdateImportTask(importID int, taskID int, task ImportTask, fields ...string) (modified bool, err error) {
+	patch, err := patchEntity(task, fields)
+	if err != nil {
+		return false, err
+	}
+	return g.updateEntity(g.importTaskURL(importID, taskID), map[string]interface{}{"task": patch}, g.checkImportResponse)
+}
+
+// UpdateImportTaskLayer partial update the layer the task creates (e.g. Name or Srs) else return error,
+// fields is an optional field mask, a list of layer json field names to send even if they are ""
+func (g *GeoServer) UpdateImportTaskLayer(importID int, taskID int, layer ImportLayer, fields ...string) (modified bool, err error) {
+	patch, err := patchEntity(layer, fields)
+	if err != nil {
+		return false, err
+	}
+	return g.updateEntity(g.importTaskURL(importID, taskID, "layer"), map[string]interface{}{"layer": patch}, g.checkImportResponse)
+}
+
+// GetImportTransforms returns the transforms of the import task,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetImportTransforms(importID int, taskID int) (transforms []*ImportTransform, err error) {
+	var transformChain ImportTransformChain
+	if err = g.requestResource(g.importTaskURL(importID, taskID, "transforms"), &transformChain); err != nil {
+		return nil, err
+	}
+	return transformChain.Transforms, nil
+}
+
+// AddImportTransform appends the transform to the transforms chain of the import task,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) AddImportTransform(importID int, taskID int, transform ImportTransform) (added bool, err error) {
+	return g.createEntity(g.importTaskURL(importID, taskID, "transforms"), transform, nil)
+}
+
+// GetImportTaskProgress returns the progress of the running import task,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetImportTaskProgress(importID int, taskID int) (progress *ImportProgress, err error) {
+	progress = &ImportProgress{}
+	if err = g.requestResource(g.importTaskURL(importID, taskID, "progress"), progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// RunImport executes the import, if async is true the import runs in background and its completion
+// can be awaited with WaitImport, else the call returns when the import is finished
+func (g *GeoServer) RunImport(importID int, async bool) (started bool, err error) {
+	httpRequest := HTTPRequest{
+		Method:   postMethod,
+		Accept:   jsonType,
+		Data:     bytes.NewBuffer([]byte("")),
+		DataType: jsonType,
+		URL:      g.importURL(importID),
+		Query:    map[string]string{"async": strconv.FormatBool(async)},
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if err = g.checkImportResponse(responseCode, response); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitImport polls the import every pollInterval until it's finished and returns it,
+// ImportError is returned if the import or any of its tasks failed, an error is returned if the timeout elapsed,
+// timeout 0 means no timeout
+func (g *GeoServer) WaitImport(importID int, pollInterval time.Duration, timeout time.Duration) (importContext *ImportContext, err error) {
+	started := time.Now()
+	for {
+		importContext, err = g.GetImport(importID)
+		if err != nil {
+			return nil, err
+		}
+		if importFinished(importContext.State) {
+			return importContext, importContextError(importContext)
+		}
+		if timeout > 0 && time.Since(started) > timeout {
+			return importContext, fmt.Errorf("import %d isn't finished in %v, state is %s", importID, timeout, importContext.State)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// importFinished returns true if the import state is final
+func importFinished(state string) bool {
+	switch state {
+	case ImportStateComplete, ImportStateCompleteError, ImportStateIncomplete, ImportStateInitError:
+		return true
+	}
+	return false
+}
+
+// importContextError returns ImportError if the import or any of its tasks failed, else nil
+func importContextError(importContext *ImportContext) error {
+	importErr := ImportError{ImportID: importContext.ID, State: importContext.State}
+	for _, t := range importContext.Tasks {
+		if t.State == ImportStateComplete || t.State == ImportStateReady || t.State == ImportStatePending {
+			continue
+		}
+		message := t.ErrorMessage
+		for _, m := range t.Messages {
+			if message == "" && m.Level == "SEVERE" {
+				message = m.Message
+			}
+		}
+		importErr.Tasks = append(importErr.Tasks, ImportTaskError{TaskID: t.ID, State: t.State, Message: message})
+	}
+	if len(importErr.Tasks) == 0 && importContext.State == ImportStateComplete {
+		return nil
+	}
+	return importErr
+}
+
+// checkImportResponse handles importer responses, importer responds 200, 202 or 204 on success
+func (g *GeoServer) checkImportResponse(statusCode int, response []byte) error {
+	if statusCode != statusOk && statusCode != statusAccepted && statusCode != statusNoContent {
+		g.logger.Error(string(response))
+		return g.GetError(statusCode, response)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImporter(t *testing.T) {
+	test_before(t)
+	_, _ = gsCatalog.CreateWorkspace(testWorkspace)
+	defer func() {
+		_, _ = gsCatalog.DeleteWorkspace(testWorkspace, true)
+	}()
+
+	importContext, err := gsCatalog.CreateImport(NewImportContext(testWorkspace, ""), false)
+	require.NoError(t, err)
+	defer func() {
+		_, _ = gsCatalog.DeleteImport(importContext.ID)
+	}()
+	assert.Equal(t, ImportStatePending, importContext.State)
+
+	tasks, err := gsCatalog.UploadImportFile(importContext.ID, testZippedShapeFile)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	modified, err := gsCatalog.UpdateImportTaskLayer(importContext.ID, tasks[0].ID, ImportLayer{Name: "museums", Srs: "EPSG:4326"})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	added, err := gsCatalog.AddImportTransform(importContext.ID, tasks[0].ID, NewReprojectTransform("EPSG:3857"))
+	assert.True(t, added)
+	assert.Nil(t, err)
+	transforms, err := gsCatalog.GetImportTransforms(importContext.ID, tasks[0].ID)
+	assert.Nil(t, err)
+	assert.Len(t, transforms, 1)
+
+	started, err := gsCatalog.RunImport(importContext.ID, true)
+	assert.True(t, started)
+	assert.Nil(t, err)
+	importContext, err = gsCatalog.WaitImport(importContext.ID, time.Second, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, ImportStateComplete, importContext.State)
+}
+
+func TestImportWorkflow(t *testing.T) {
+	var mu sync.Mutex
+	state := ImportStatePending
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/imports":
+			var request map[string]ImportContext
+			_ = json.Unmarshal(body, &request)
+			if request["import"].TargetWorkspace.Workspace.Name != "ws" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"import":{"id":3,"state":"PENDING","targetWorkspace":{"workspace":{"name":"ws"}}}}`))
+		case "PUT /rest/imports/3/tasks/roads.zip":
+			if string(body) != "zipped data" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"task":{"id":0,"state":"NO_CRS","layer":{"name":"roads"}}}`))
+		case "POST /rest/imports/3/tasks":
+			if form, _ := url.ParseQuery(string(body)); form.Get("url") != "file:/data/rivers" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"tasks":[{"id":1,"state":"READY"},{"id":2,"state":"READY"}]}`))
+		case "PUT /rest/imports/3/tasks/0/layer":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /rest/imports/3/tasks/1/transforms":
+			w.WriteHeader(http.StatusCreated)
+		case "POST /rest/imports/3":
+			state = ImportStateRunning
+			w.WriteHeader(http.StatusAccepted)
+		case "GET /rest/imports/3":
+			response := `{"import":{"id":3,"state":"` + state + `","tasks":[{"id":0,"state":"COMPLETE"},{"id":1,"state":"ERROR","errorMessage":"date can't be parsed"},{"id":2,"state":"ERROR","messages":[{"level":"SEVERE","message":"table exists"}]}]}}`
+			state = ImportStateCompleteError
+			_, _ = w.Write([]byte(response))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+	shapeFile := t.TempDir() + "/roads.zip"
+	assert.Nil(t, os.WriteFile(shapeFile, []byte("zipped data"), 0o600))
+
+	importContext, err := gsCatalog.CreateImport(NewImportContext("ws", ""), true)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, importContext.ID)
+	tasks, err := gsCatalog.UploadImportFile(3, shapeFile)
+	assert.Nil(t, err)
+	assert.Equal(t, ImportTaskStateNoCRS, tasks[0].State)
+	tasks, err = gsCatalog.AddImportURL(3, "file:/data/rivers")
+	assert.Nil(t, err)
+	assert.Len(t, tasks, 2)
+	modified, err := gsCatalog.UpdateImportTaskLayer(3, 0, ImportLayer{Srs: "EPSG:4326"})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	added, err := gsCatalog.AddImportTransform(3, 1, NewDateFormatTransform("date", "yyyyMMdd"))
+	assert.True(t, added)
+	assert.Nil(t, err)
+	started, err := gsCatalog.RunImport(3, true)
+	assert.True(t, started)
+	assert.Nil(t, err)
+
+	importContext, err = gsCatalog.WaitImport(3, time.Millisecond, time.Second)
+	assert.Equal(t, ImportStateCompleteError, importContext.State)
+	importErr, ok := err.(ImportError)
+	assert.True(t, ok)
+	assert.Equal(t, []ImportTaskError{
+		{TaskID: 1, State: ImportTaskStateError, Message: "date can't be parsed"},
+		{TaskID: 2, State: ImportTaskStateError, Message: "table exists"},
+	}, importErr.Tasks)
+
+	_, err = gsCatalog.GetImport(4)
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementImporterService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	ImporterServiceType := reflect.TypeOf((*ImporterService)(nil)).Elem()
+	check := gsCatalog.Implements(ImporterServiceType)
+	assert.True(t, check)
+}
@@ -0,0 +1,275 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// IteratorOptions limits the iterated items, Offset skips the first items, Limit stops the iteration after Limit items
+// (0 means no limit), geoserver rest api doesn't page the catalog listings so the options are applied
+// while the response is decoded, the rest of the response isn't read
+type IteratorOptions struct {
+	Offset int
+	Limit  int
+}
+
+// jsonIterator decodes the items of the json collection one by one,
+// path is the keys of the collection, e.g. "layers", "layer" for {"layers":{"layer":[...]}}
+type jsonIterator struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+	options IteratorOptions
+	items   []json.RawMessage // the single item (not an array) of the collection
+	array   bool
+	done    bool
+	closed  bool
+	count   int
+	err     error
+}
+
+func (g *GeoServer) iterate(targetURL string, options IteratorOptions, path ...string) (*jsonIterator, error) {
+	response, err := g.doResourceRequest(getMethod, targetURL, jsonType, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	it := &jsonIterator{body: response.Body, decoder: json.NewDecoder(response.Body), options: options}
+	if err = it.open(path); err != nil {
+		it.Close()
+		return nil, fmt.Errorf("can't parse %s response: %v", targetURL, err)
+	}
+	return it, nil
+}
+
+// open moves the decoder to the first item of the collection
+func (it *jsonIterator) open(path []string) error {
+	for _, key := range path {
+		token, err := it.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token != json.Delim('{') {
+			// geoserver returns "" for the empty collection
+			it.done = true
+			return nil
+		}
+		if err = it.seek(key); err != nil {
+			return err
+		}
+		if it.done {
+			return nil
+		}
+	}
+	token, err := it.decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('['):
+		it.array = true
+	case json.Delim('{'):
+		// the collection of the single item isn't wrapped to the array
+		item, err := it.readObject()
+		if err != nil {
+			return err
+		}
+		it.items = []json.RawMessage{item}
+	default:
+		it.done = true
+	}
+	return nil
+}
+
+// seek skips the object fields up to the key, the iterator is done if the object doesn't contain the key
+func (it *jsonIterator) seek(key string) error {
+	for it.decoder.More() {
+		token, err := it.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == key {
+			return nil
+		}
+		var skipped json.RawMessage
+		if err = it.decoder.Decode(&skipped); err != nil {
+			return err
+		}
+	}
+	it.done = true
+	return nil
+}
+
+// readObject reads the rest of the object which opening brace is already read
+func (it *jsonIterator) readObject() (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	for it.decoder.More() {
+		token, err := it.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected token %v", token)
+		}
+		var value json.RawMessage
+		if err = it.decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields[key] = value
+	}
+	return json.Marshal(fields)
+}
+
+// next decodes the next item to v, false is returned when the iteration is finished or failed
+func (it *jsonIterator) next(v interface{}) bool {
+	for !it.done {
+		if it.options.Limit > 0 && it.count >= it.options.Limit+it.options.Offset {
+			break
+		}
+		var item json.RawMessage
+		if it.array {
+			if !it.decoder.More() {
+				break
+			}
+			if it.err = it.decoder.Decode(&item); it.err != nil {
+				break
+			}
+		} else {
+			if len(it.items) == 0 {
+				break
+			}
+			item, it.items = it.items[0], it.items[1:]
+		}
+		it.count++
+		if it.count <= it.options.Offset {
+			continue
+		}
+		if it.err = json.Unmarshal(item, v); it.err != nil {
+			break
+		}
+		return true
+	}
+	it.Close()
+	return false
+}
+
+// Close stops the iteration and releases the response
+func (it *jsonIterator) Close() error {
+	it.done = true
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	return it.body.Close()
+}
+
+// ResourceIterator iterates the catalog resources of the listing, the iterator must be closed if the iteration is stopped early:
+//
+//	it, err := gsCatalog.IterateLayers("", IteratorOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Resource().Name)
+//	}
+//	return it.Err()
+type ResourceIterator struct {
+	it       *jsonIterator
+	resource *Resource
+}
+
+// Next decodes the next resource, false is returned when the iteration is finished or failed
+func (i *ResourceIterator) Next() bool {
+	i.resource = &Resource{}
+	return i.it.next(i.resource)
+}
+
+// Resource returns the current resource
+func (i *ResourceIterator) Resource() *Resource {
+	return i.resource
+}
+
+// Err returns the error occurred during the iteration
+func (i *ResourceIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops the iteration
+func (i *ResourceIterator) Close() error {
+	return i.it.Close()
+}
+
+func (g *GeoServer) iterateResources(targetURL string, options IteratorOptions, path ...string) (*ResourceIterator, error) {
+	it, err := g.iterate(targetURL, options, path...)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourceIterator{it: it}, nil
+}
+
+// IterateLayers iterates the layers of the workspace, all layers if workspace is "", see GetLayers
+func (g *GeoServer) IterateLayers(workspaceName string, options IteratorOptions) (*ResourceIterator, error) {
+	if workspaceName != "" {
+		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
+	}
+	return g.iterateResources(g.ParseURL("rest", workspaceName, "layers"), options, "layers", "layer")
+}
+
+// IterateFeatureTypes iterates the feature types of the datastore, see GetFeatureTypes
+func (g *GeoServer) IterateFeatureTypes(workspaceName string, datastoreName string, options IteratorOptions) (*ResourceIterator, error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "datastores", datastoreName, "featuretypes")
+	return g.iterateResources(targetURL, options, "featureTypes", "featureType")
+}
+
+// IterateStyles iterates the styles of the workspace, the global styles if workspace is "", see GetStyles
+func (g *GeoServer) IterateStyles(workspaceName string, options IteratorOptions) (*ResourceIterator, error) {
+	if workspaceName != "" {
+		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
+	}
+	return g.iterateResources(g.ParseURL("rest", workspaceName, "styles"), options, "styles", "style")
+}
+
+// IterateCoverages iterates the coverages of the workspace, see GetCoverages
+func (g *GeoServer) IterateCoverages(workspaceName string, options IteratorOptions) (*ResourceIterator, error) {
+	return g.iterateResources(g.ParseURL("rest", "workspaces", workspaceName, "coverages"), options, "coverages", "coverage")
+}
+
+// UserIterator iterates the users of the user group service, see ResourceIterator
+type UserIterator struct {
+	it   *jsonIterator
+	user User
+}
+
+// Next decodes the next user, false is returned when the iteration is finished or failed
+func (i *UserIterator) Next() bool {
+	i.user = User{}
+	return i.it.next(&i.user)
+}
+
+// User returns the current user
+func (i *UserIterator) User() User {
+	return i.user
+}
+
+// Err returns the error occurred during the iteration
+func (i *UserIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops the iteration
+func (i *UserIterator) Close() error {
+	return i.it.Close()
+}
+
+// IterateUsers iterates the users of the service, the default service if service is "", see GetUsers
+func (g *GeoServer) IterateUsers(service string, options IteratorOptions) (*UserIterator, error) {
+	if service == "" {
+		service = "default"
+	}
+	it, err := g.iterate(g.ParseURL("rest", "security", "usergroup", "service", service, "users"), options, "users")
+	if err != nil {
+		return nil, err
+	}
+	return &UserIterator{it: it}, nil
+}
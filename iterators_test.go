@@ -0,0 +1,76 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/layers":
+			w.Write([]byte(`{"layers":{"layer":[{"name":"ws:a","href":"a.json"},{"name":"ws:b"},{"name":"ws:c"},{"name":"ws:d"}]}}`))
+		case "/rest/workspaces/ws/styles":
+			w.Write([]byte(`{"styles":{"style":{"name":"single"}}}`))
+		case "/rest/workspaces/ws/coverages":
+			w.Write([]byte(`{"coverages":""}`))
+		case "/rest/security/usergroup/service/default/users":
+			w.Write([]byte(`{"users":[{"userName":"admin","enabled":true}]}`))
+		case "/rest/workspaces/ws/datastores/ds/featuretypes":
+			w.Write([]byte(`{"featureTypes":{"featureType":[{"name":"roads"},{"name":`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	collect := func(it *ResourceIterator) (names []string) {
+		for it.Next() {
+			names = append(names, it.Resource().Name)
+		}
+		assert.Nil(t, it.Err())
+		return
+	}
+
+	it, err := catalog.IterateLayers("", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ws:a", "ws:b", "ws:c", "ws:d"}, collect(it))
+
+	it, err = catalog.IterateLayers("", IteratorOptions{Offset: 1, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ws:b", "ws:c"}, collect(it))
+
+	it, err = catalog.IterateLayers("", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.True(t, it.Next())
+	assert.Equal(t, "a.json", it.Resource().Href)
+	assert.Nil(t, it.Close())
+	assert.False(t, it.Next())
+
+	it, err = catalog.IterateStyles("ws", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"single"}, collect(it))
+
+	it, err = catalog.IterateCoverages("ws", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, collect(it))
+
+	users, err := catalog.IterateUsers("", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.True(t, users.Next())
+	assert.Equal(t, User{Name: "admin", Enabled: true}, users.User())
+	assert.False(t, users.Next())
+
+	it, err = catalog.IterateFeatureTypes("ws", "ds", IteratorOptions{})
+	assert.Nil(t, err)
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Err())
+
+	_, err = catalog.IterateLayers("missing", IteratorOptions{})
+	assert.True(t, isNotFoundError(err))
+}
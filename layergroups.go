@@ -1,24 +1,59 @@
 package geoserver
 
 import (
-	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 )
 
+const (
+	LayerGroupModeSingle          = "SINGLE"           //the group is exposed as a single layer with a name
+	LayerGroupModeOpaqueContainer = "OPAQUE_CONTAINER" //the group is exposed as a single layer, the contained layers aren't listed
+	LayerGroupModeNamed           = "NAMED"            //the group is exposed as a named tree of the contained layers
+	LayerGroupModeContainer       = "CONTAINER"        //the group is exposed as a tree of the contained layers without a name
+	LayerGroupModeEO              = "EO"               //Earth Observation tree, the root layer is exposed with the contained layers as children
+
+	PublishableTypeLayer      = "layer"      //publishable item is a layer
+	PublishableTypeLayerGroup = "layerGroup" //publishable item is a nested layergroup
+
+	layerGroupDefaultCrs = "EPSG:4326" //crs of the calculated bounds if the layergroup bounds crs isn't set
+)
+
+var layerGroupModes = map[string]bool{
+	LayerGroupModeSingle:          true,
+	LayerGroupModeOpaqueContainer: true,
+	LayerGroupModeNamed:           true,
+	LayerGroupModeContainer:       true,
+	LayerGroupModeEO:              true,
+}
+
 //PublishedGroupLayers geoserver published layers
 type PublishedGroupLayers []*GroupPublishableItem
 
 //GroupPublishableItem geoserver Group
 type GroupPublishableItem struct {
-	Type string `json:"@type,omitempty" xml:"type"`
+	Type string `json:"@type,omitempty" xml:"type,attr,omitempty"`
 	Name string `json:"name,omitempty" xml:"name"`
-	Href string `json:"href,omitempty" xml:"href"`
+	Href string `json:"href,omitempty" xml:"-"`
+}
+
+//NewLayerPublishable returns publishable item referencing the layer, layerName can be qualified as ${workspace}:${layer}
+func NewLayerPublishable(layerName string) *GroupPublishableItem {
+	return &GroupPublishableItem{Type: PublishableTypeLayer, Name: layerName}
+}
+
+//NewLayerGroupPublishable returns publishable item referencing the nested layergroup,
+//layerGroupName can be qualified as ${workspace}:${layergroup}
+func NewLayerGroupPublishable(layerGroupName string) *GroupPublishableItem {
+	return &GroupPublishableItem{Type: PublishableTypeLayerGroup, Name: layerGroupName}
 }
 
 //LayerGroupKeywords geoserver layergroups keywords
 type LayerGroupKeywords struct {
-	Keyword []*string `json:"keyword,omitempty"`
+	Keyword []*string `json:"keyword,omitempty" xml:"string"`
 }
 
 //Publishables Geoserver Published Layers
@@ -45,22 +80,251 @@ func (u *PublishedGroupLayers) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-//LayerGroupStyles geoserver layergroup styles
+//LayerGroupStyles geoserver layergroup styles,
+//each entry is the style of the publishable item with the same index, nil entry means the default style
 type LayerGroupStyles struct {
 	Style []*Resource `json:"style,omitempty" xml:"style"`
 }
 
-//LayerGroup geoserver layergroup details
+//UnmarshalJSON custom deserialization to handle a single style object and empty (default) style entries
+func (u *LayerGroupStyles) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Style json.RawMessage `json:"style,omitempty"`
+	}
+	*u = LayerGroupStyles{}
+	if isEmptyJSONList(data) {
+		// geoserver returns "" for the empty list
+		return nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Style) == 0 {
+		return nil
+	}
+	var items []json.RawMessage
+	if raw.Style[0] == '[' {
+		if err := json.Unmarshal(raw.Style, &items); err != nil {
+			return err
+		}
+	} else {
+		items = []json.RawMessage{raw.Style}
+	}
+	for _, item := range items {
+		if isEmptyJSONList(item) {
+			// the default style of the publishable item
+			u.Style = append(u.Style, nil)
+			continue
+		}
+		style := &Resource{}
+		if err := json.Unmarshal(item, style); err != nil {
+			return err
+		}
+		u.Style = append(u.Style, style)
+	}
+	return nil
+}
+
+//MarshalJSON custom serialization to keep empty (default) style entries aligned with the publishables
+func (u LayerGroupStyles) MarshalJSON() ([]byte, error) {
+	if len(u.Style) == 0 {
+		return []byte("{}"), nil
+	}
+	items := make([]interface{}, 0, len(u.Style))
+	for _, style := range u.Style {
+		if style == nil {
+			items = append(items, "")
+		} else {
+			items = append(items, style)
+		}
+	}
+	return json.Marshal(struct {
+		Style []interface{} `json:"style"`
+	}{items})
+}
+
+//MarshalXML custom serialization to keep empty (default) style entries aligned with the publishables
+func (u LayerGroupStyles) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, style := range u.Style {
+		styleStart := xml.StartElement{Name: xml.Name{Local: "style"}}
+		if style == nil {
+			style = &Resource{}
+		}
+		if err := e.EncodeElement(style, styleStart); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+//UnmarshalXML custom deserialization, an empty style element means the default style
+func (u *LayerGroupStyles) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Style []Resource `xml:"style"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*u = LayerGroupStyles{}
+	for i := range raw.Style {
+		if raw.Style[i].Name == "" {
+			u.Style = append(u.Style, nil)
+		} else {
+			u.Style = append(u.Style, &raw.Style[i])
+		}
+	}
+	return nil
+}
+
+//InternationalString holds a text translated to several languages, the key is a language code
+type InternationalString map[string]string
+
+//MarshalXML serializes the translations as elements named by the language code
+func (s InternationalString) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	languages := make([]string, 0, len(s))
+	for language := range s {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, language := range languages {
+		if err := e.EncodeElement(s[language], xml.StartElement{Name: xml.Name{Local: language}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+//UnmarshalXML deserializes the translations from elements named by the language code
+func (s *InternationalString) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*s = InternationalString{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var text string
+			if err = d.DecodeElement(&text, &t); err != nil {
+				return err
+			}
+			(*s)[t.Name.Local] = text
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+//LayerGroup geoserver layergroup details,
+//RootLayer and RootLayerStyle are used by the EO mode only
 type LayerGroup struct {
-	Name          string             `json:"name,omitempty" xml:"name"`
-	Mode          string             `json:"mode,omitempty" xml:"mode"`
-	Title         string             `json:"title,omitempty" xml:"title"`
-	Workspace     *Resource          `json:"workspace,omitempty" xml:"workspace"`
-	Publishables  Publishables       `json:"publishables,omitempty" xml:"publishables"`
-	Styles        LayerGroupStyles   `json:"styles,omitempty" xml:"styles"`
-	Bounds        NativeBoundingBox  `json:"bounds,omitempty" xml:"bounds"`
-	MetadataLinks []*MetadataLink    `json:"metadataLinks,omitempty" xml:"metadataLinks"`
-	Keywords      LayerGroupKeywords `json:"keywords,omitempty" xml:"keywords"`
+	XMLName               xml.Name            `json:"-" xml:"layerGroup"`
+	Name                  string              `json:"name,omitempty" xml:"name,omitempty"`
+	Mode                  string              `json:"mode,omitempty" xml:"mode,omitempty"`
+	Title                 string              `json:"title,omitempty" xml:"title,omitempty"`
+	Abstract              string              `json:"abstractTxt,omitempty" xml:"abstractTxt,omitempty"`
+	InternationalTitle    InternationalString `json:"internationalTitle,omitempty" xml:"internationalTitle,omitempty"`
+	InternationalAbstract InternationalString `json:"internationalAbstract,omitempty" xml:"internationalAbstract,omitempty"`
+	Workspace             *Resource           `json:"workspace,omitempty" xml:"workspace,omitempty"`
+	Enabled               *bool               `json:"enabled,omitempty" xml:"enabled,omitempty"`
+	Advertised            *bool               `json:"advertised,omitempty" xml:"advertised,omitempty"`
+	RootLayer             *Resource           `json:"rootLayer,omitempty" xml:"rootLayer,omitempty"`
+	RootLayerStyle        *Resource           `json:"rootLayerStyle,omitempty" xml:"rootLayerStyle,omitempty"`
+	Publishables          Publishables        `json:"publishables,omitempty" xml:"publishables"`
+	Styles                LayerGroupStyles    `json:"styles,omitempty" xml:"styles"`
+	Bounds                NativeBoundingBox   `json:"bounds,omitempty" xml:"bounds"`
+	MetadataLinks         []*MetadataLink     `json:"metadataLinks,omitempty" xml:"metadataLinks>metadataLink,omitempty"`
+	Keywords              LayerGroupKeywords  `json:"keywords,omitempty" xml:"keywords"`
+	Attribution           *Attribution        `json:"attribution,omitempty" xml:"attribution,omitempty"`
+}
+
+//AddPublishable appends publishable item with its style to the layergroup, nil style means the default style
+func (lg *LayerGroup) AddPublishable(item *GroupPublishableItem, style *Resource) {
+	lg.alignStyles()
+	lg.Publishables.Published = append(lg.Publishables.Published, item)
+	lg.Styles.Style = append(lg.Styles.Style, style)
+}
+
+//InsertPublishable inserts publishable item with its style to the layergroup at the position index,
+//nil style means the default style
+func (lg *LayerGroup) InsertPublishable(index int, item *GroupPublishableItem, style *Resource) error {
+	lg.alignStyles()
+	if index < 0 || index > len(lg.Publishables.Published) {
+		return fmt.Errorf("publishable index %d is out of range", index)
+	}
+	lg.Publishables.Published = append(lg.Publishables.Published, nil)
+	copy(lg.Publishables.Published[index+1:], lg.Publishables.Published[index:])
+	lg.Publishables.Published[index] = item
+	lg.Styles.Style = append(lg.Styles.Style, nil)
+	copy(lg.Styles.Style[index+1:], lg.Styles.Style[index:])
+	lg.Styles.Style[index] = style
+	return nil
+}
+
+//RemovePublishable removes all publishable items with name and their styles from the layergroup,
+//returns true if any item was removed
+func (lg *LayerGroup) RemovePublishable(name string) (removed bool) {
+	lg.alignStyles()
+	published := lg.Publishables.Published[:0]
+	styles := lg.Styles.Style[:0]
+	for i, item := range lg.Publishables.Published {
+		if item != nil && item.Name == name {
+			removed = true
+			continue
+		}
+		published = append(published, item)
+		styles = append(styles, lg.Styles.Style[i])
+	}
+	lg.Publishables.Published = published
+	lg.Styles.Style = styles
+	return
+}
+
+//MovePublishable moves publishable item with its style from the position from to the position to
+func (lg *LayerGroup) MovePublishable(from int, to int) error {
+	lg.alignStyles()
+	count := len(lg.Publishables.Published)
+	if from < 0 || from >= count || to < 0 || to >= count {
+		return fmt.Errorf("publishable index is out of range [0, %d)", count)
+	}
+	item, style := lg.Publishables.Published[from], lg.Styles.Style[from]
+	for i := from; i < to; i++ {
+		lg.Publishables.Published[i], lg.Styles.Style[i] = lg.Publishables.Published[i+1], lg.Styles.Style[i+1]
+	}
+	for i := from; i > to; i-- {
+		lg.Publishables.Published[i], lg.Styles.Style[i] = lg.Publishables.Published[i-1], lg.Styles.Style[i-1]
+	}
+	lg.Publishables.Published[to], lg.Styles.Style[to] = item, style
+	return nil
+}
+
+//alignStyles pads or truncates the styles list to the length of the publishables list
+func (lg *LayerGroup) alignStyles() {
+	count := len(lg.Publishables.Published)
+	for len(lg.Styles.Style) < count {
+		lg.Styles.Style = append(lg.Styles.Style, nil)
+	}
+	lg.Styles.Style = lg.Styles.Style[:count]
+}
+
+//validateLayerGroup checks the layergroup and its mode
+func validateLayerGroup(layerGroup *LayerGroup) error {
+	if layerGroup == nil {
+		return errors.New("layergroup is nil")
+	}
+	if layerGroup.Mode != "" && !layerGroupModes[layerGroup.Mode] {
+		return fmt.Errorf("unknown layergroup mode %s", layerGroup.Mode)
+	}
+	if layerGroup.Mode == LayerGroupModeEO && (layerGroup.RootLayer == nil || layerGroup.RootLayer.Name == "") {
+		return errors.New("root layer is required for the EO layergroup mode")
+	}
+	return nil
 }
 
 type layerGroupResponse struct {
@@ -72,11 +336,24 @@ type layerGroupDetailsResponse struct {
 	LayerGroup *LayerGroup `json:"layerGroup,omitempty"`
 }
 
+//layerGroupWireEntity returns the layergroup wrapped for the wire format defined by dataType (jsonType or xmlType)
+func layerGroupWireEntity(layerGroup *LayerGroup, dataType string) interface{} {
+	if isXMLType(dataType) {
+		return layerGroup
+	}
+	return layerGroupDetailsResponse{LayerGroup: layerGroup}
+}
+
 // LayerGroupService define  geoserver layergroup operations
 type LayerGroupService interface {
 	GetLayerGroups(workspaceName string) (layerGroups []*Resource, err error)
 	GetLayerGroup(workspaceName string, layerGroupName string) (layer *LayerGroup, err error)
 	CreateLayerGroup(workspaceName string, layerGroup *LayerGroup) (created bool, err error)
+	UpdateLayerGroup(workspaceName string, layerGroupName string, layerGroup *LayerGroup, fields ...string) (modified bool, err error)
+	GetLayerGroupXML(workspaceName string, layerGroupName string) (layer *LayerGroup, err error)
+	CreateLayerGroupXML(workspaceName string, layerGroup *LayerGroup) (created bool, err error)
+	UpdateLayerGroupXML(workspaceName string, layerGroupName string, layerGroup *LayerGroup) (modified bool, err error)
+	CalculateLayerGroupBounds(workspaceName string, layerGroup *LayerGroup) (bounds NativeBoundingBox, err error)
 	DeleteLayerGroup(workspaceName string, layerGroupName string) (deleted bool, err error)
 }
 
@@ -135,33 +412,186 @@ func (g *GeoServer) GetLayerGroup(workspaceName string, layerGroupName string) (
 //CreateLayerGroup create specific LayerGroup in geoserver return created=true else created=false and the error,
 //if workspace is "" the it will return geoserver public layer with ${layerName}
 func (g *GeoServer) CreateLayerGroup(workspaceName string, layerGroup *LayerGroup) (created bool, err error) {
+	return g.writeLayerGroup(workspaceName, "", layerGroup, jsonType, nil)
+}
+
+//CreateLayerGroupXML does the same as CreateLayerGroup using xml as the wire format
+func (g *GeoServer) CreateLayerGroupXML(workspaceName string, layerGroup *LayerGroup) (created bool, err error) {
+	return g.writeLayerGroup(workspaceName, "", layerGroup, xmlType, nil)
+}
+
+//UpdateLayerGroup partial update geoserver layergroup with name layerGroupName else return error,
+//if workspace is "" will update public layergroup,
+//fields is an optional field mask, a list of layergroup json field names to send even if they are empty (e.g. "styles"),
+//without fields only the fields set in the layerGroup are sent, the others are left untouched on the server,
+//if the publishables are set and layerGroup.Bounds is empty the bounds are recalculated
+//in the crs of layerGroup.Bounds or the crs of the current bounds if it isn't set, see CalculateLayerGroupBounds
+func (g *GeoServer) UpdateLayerGroup(workspaceName string, layerGroupName string, layerGroup *LayerGroup, fields ...string) (modified bool, err error) {
+	return g.writeLayerGroup(workspaceName, layerGroupName, layerGroup, jsonType, fields)
+}
+
+//UpdateLayerGroupXML does the same as UpdateLayerGroup using xml as the wire format, the whole layerGroup is sent
+func (g *GeoServer) UpdateLayerGroupXML(workspaceName string, layerGroupName string, layerGroup *LayerGroup) (modified bool, err error) {
+	return g.writeLayerGroup(workspaceName, layerGroupName, layerGroup, xmlType, nil)
+}
+
+//GetLayerGroupXML does the same as GetLayerGroup using xml as the wire format
+func (g *GeoServer) GetLayerGroupXML(workspaceName string, layerGroupName string) (layerGroup *LayerGroup, err error) {
 	if workspaceName != "" {
 		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
 	}
-	group := layerGroupDetailsResponse{LayerGroup: layerGroup}
-	serializedGroup, _ := g.SerializeStruct(group)
-	targetURL := g.ParseURL("rest", workspaceName, "layergroups")
-	data := bytes.NewBuffer(serializedGroup)
+	targetURL := g.ParseURL("rest", workspaceName, "layergroups", layerGroupName)
+	layerGroup = &LayerGroup{}
+	if err = g.requestResourceAs(targetURL, xmlType, layerGroup); err != nil {
+		return &LayerGroup{}, err
+	}
+	return
+}
 
-	httpRequest := HTTPRequest{
-		Method:   postMethod,
-		Accept:   jsonType,
-		Data:     data,
-		DataType: jsonType,
-		URL:      targetURL,
-		Query:    nil,
+//writeLayerGroup creates (if layerGroupName is "") or updates the layergroup serialized to dataType format (jsonType or xmlType),
+//the json update is sent as the partial update with the fields mask, the layerGroup itself isn't changed
+func (g *GeoServer) writeLayerGroup(workspaceName string, layerGroupName string, layerGroup *LayerGroup, dataType string, fields []string) (done bool, err error) {
+	if err = validateLayerGroup(layerGroup); err != nil {
+		return false, err
 	}
-	response, responseCode := g.DoRequest(httpRequest)
-	if responseCode != statusCreated {
-		g.logger.Error(string(response))
-		created = false
-		err = g.GetError(responseCode, response)
-		return
+	group := *layerGroup
+	if len(group.Publishables.Published) != 0 {
+		group.Styles.Style = append([]*Resource(nil), group.Styles.Style...)
+		group.alignStyles()
+		if layerGroupName != "" && IsEmpty(group.Bounds.BoundingBox) {
+			if group.Bounds.Crs == nil {
+				current, err := g.GetLayerGroup(workspaceName, layerGroupName)
+				if err != nil {
+					return false, err
+				}
+				group.Bounds.Crs = current.Bounds.Crs
+			}
+			if group.Bounds, err = g.CalculateLayerGroupBounds(workspaceName, &group); err != nil {
+				return false, err
+			}
+		}
+	}
+	if workspaceName != "" {
+		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
+	}
+	entity := layerGroupWireEntity(&group, dataType)
+	if layerGroupName == "" {
+		targetURL := g.ParseURL("rest", workspaceName, "layergroups")
+		return g.writeEntityAs(targetURL, postMethod, dataType, entity, nil)
+	}
+	targetURL := g.ParseURL("rest", workspaceName, "layergroups", layerGroupName)
+	if !isXMLType(dataType) {
+		patch, err := patchEntity(&group, fields)
+		if err != nil {
+			return false, err
+		}
+		entity = map[string]interface{}{"layerGroup": patch}
+	}
+	return g.writeEntityAs(targetURL, putMethod, dataType, entity, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
+//CalculateLayerGroupBounds calculates the layergroup bounds in the crs of layerGroup.Bounds (EPSG:4326 if it isn't set)
+//as an union of the bounds of the published feature types and coverages and the nested layergroups bounds,
+//the native bounds of the items in the same crs and the lat/lon bounds for EPSG:4326 are used,
+//workspaceName is the workspace of the layergroup or ""
+func (g *GeoServer) CalculateLayerGroupBounds(workspaceName string, layerGroup *LayerGroup) (bounds NativeBoundingBox, err error) {
+	crs := &CRSType{Class: "string", Value: layerGroupDefaultCrs}
+	if layerGroup.Bounds.Crs != nil && layerGroup.Bounds.Crs.Value != "" {
+		crs = layerGroup.Bounds.Crs
+	}
+	box := BoundingBox{Minx: math.Inf(1), Miny: math.Inf(1), Maxx: math.Inf(-1), Maxy: math.Inf(-1)}
+	items := layerGroup.Publishables.Published
+	if layerGroup.Mode == LayerGroupModeEO && layerGroup.RootLayer != nil {
+		items = append(PublishedGroupLayers{NewLayerPublishable(layerGroup.RootLayer.Name)}, items...)
 	}
-	created = true
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		var itemBox BoundingBox
+		if item.Type == PublishableTypeLayerGroup {
+			itemBox, err = g.nestedLayerGroupBounds(workspaceName, item.Name, crs)
+		} else {
+			itemBox, err = g.layerBounds(workspaceName, item.Name, crs.Value)
+		}
+		if err != nil {
+			return
+		}
+		box.Minx, box.Miny = math.Min(box.Minx, itemBox.Minx), math.Min(box.Miny, itemBox.Miny)
+		box.Maxx, box.Maxy = math.Max(box.Maxx, itemBox.Maxx), math.Max(box.Maxy, itemBox.Maxy)
+	}
+	if math.IsInf(box.Minx, 0) {
+		return bounds, errors.New("can't calculate layergroup bounds, no published items")
+	}
+	bounds = NativeBoundingBox{BoundingBox: box, Crs: crs}
 	return
 }
 
+//layerBounds returns bounds of the layer resource in the crs
+func (g *GeoServer) layerBounds(workspaceName string, layerName string, crs string) (box BoundingBox, err error) {
+	if ws, _ := splitQualifiedName(layerName); ws == "" && workspaceName != "" {
+		layerName = fmt.Sprintf("%s:%s", workspaceName, layerName)
+	}
+	layer, err := g.GetLayer("", layerName)
+	if err != nil {
+		return
+	}
+	var resourceResponse struct {
+		FeatureType *FeatureType `json:"featureType,omitempty"`
+		Coverage    *Coverage    `json:"coverage,omitempty"`
+	}
+	if err = g.requestResource(layer.Resource.Href, &resourceResponse); err != nil {
+		return
+	}
+	switch {
+	case resourceResponse.FeatureType != nil:
+		featureType := resourceResponse.FeatureType
+		return resourceBounds(layerName, featureType.Srs, featureType.NativeBoundingBox, featureType.LatLonBoundingBox, crs)
+	case resourceResponse.Coverage != nil:
+		coverage := resourceResponse.Coverage
+		return resourceBounds(layerName, coverage.Srs, coverage.NativeBoundingBox, coverage.LatLonBoundingBox, crs)
+	}
+	return box, fmt.Errorf("can't get bounds of the layer %s", layerName)
+}
+
+//resourceBounds returns the native bounds of the resource in the crs or the lat/lon bounds for EPSG:4326
+func resourceBounds(name string, srs string, native *NativeBoundingBox, latLon *LatLonBoundingBox, crs string) (box BoundingBox, err error) {
+	switch {
+	case native != nil && (srs == crs || native.Crs != nil && native.Crs.Value == crs):
+		return native.BoundingBox, nil
+	case latLon != nil && crs == layerGroupDefaultCrs:
+		return latLon.BoundingBox, nil
+	}
+	return box, fmt.Errorf("can't get bounds of the layer %s in %s, set the layergroup bounds explicitly", name, crs)
+}
+
+//nestedLayerGroupBounds returns bounds of the nested layergroup in the crs
+func (g *GeoServer) nestedLayerGroupBounds(workspaceName string, layerGroupName string, crs *CRSType) (box BoundingBox, err error) {
+	if ws, name := splitQualifiedName(layerGroupName); ws != "" {
+		workspaceName, layerGroupName = ws, name
+	}
+	group, err := g.GetLayerGroup(workspaceName, layerGroupName)
+	if err != nil {
+		return
+	}
+	groupCrs := layerGroupDefaultCrs
+	if group.Bounds.Crs != nil {
+		groupCrs = group.Bounds.Crs.Value
+	}
+	if !IsEmpty(group.Bounds) && groupCrs == crs.Value {
+		return group.Bounds.BoundingBox, nil
+	}
+	group.Bounds = NativeBoundingBox{Crs: crs}
+	bounds, err := g.CalculateLayerGroupBounds(workspaceName, group)
+	return bounds.BoundingBox, err
+}
+
 //DeleteLayerGroup delete geoserver layergroup else return error,
 //if workspace is "" will delete public layergroup with name ${layerGroupName} if exists
 func (g *GeoServer) DeleteLayerGroup(workspaceName string, layerGroupName string) (deleted bool, err error) {
@@ -2,6 +2,9 @@ package geoserver
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -115,3 +118,189 @@ func TestUnmarshalJSON(t *testing.T) {
 	singleErr := json.Unmarshal(singleOneLayerData, &singleObj)
 	assert.Nil(t, singleErr)
 }
+func TestUpdateLayerGroup(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	layerGroup, err := gsCatalog.GetLayerGroup("", "tiger-ny")
+	assert.Nil(t, err)
+	layerGroup.Title = "Tiger NY"
+	layerGroup.AddPublishable(NewLayerPublishable("tiger:poi"), &Resource{Name: "point"})
+	layerGroup.Bounds = NativeBoundingBox{}
+	modified, err := gsCatalog.UpdateLayerGroup("", "tiger-ny", layerGroup)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	layerGroup.Mode = "DUMMY"
+	modified, err = gsCatalog.UpdateLayerGroup("", "tiger-ny", layerGroup)
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+	modified, err = gsCatalog.UpdateLayerGroup("", "dummy_layer_group", &LayerGroup{Mode: LayerGroupModeSingle, Bounds: layerGroup.Bounds})
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+}
+func TestLayerGroupPublishables(t *testing.T) {
+	layerGroup := LayerGroup{Name: "nested", Mode: LayerGroupModeNamed}
+	layerGroup.AddPublishable(NewLayerPublishable("tiger:poly_landmarks"), &Resource{Name: "polygon"})
+	layerGroup.AddPublishable(NewLayerPublishable("tiger:poi"), nil)
+	layerGroup.AddPublishable(NewLayerGroupPublishable("tiger-ny"), nil)
+	assert.Nil(t, layerGroup.InsertPublishable(0, NewLayerPublishable("tiger:tiger_roads"), &Resource{Name: "line"}))
+	assert.NotNil(t, layerGroup.InsertPublishable(5, NewLayerPublishable("tiger:tiger_roads"), nil))
+	assert.Nil(t, layerGroup.MovePublishable(0, 3))
+	assert.NotNil(t, layerGroup.MovePublishable(0, 4))
+	assert.True(t, layerGroup.RemovePublishable("tiger:poi"))
+	assert.False(t, layerGroup.RemovePublishable("tiger:poi"))
+	names := []string{}
+	for _, item := range layerGroup.Publishables.Published {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"tiger:poly_landmarks", "tiger-ny", "tiger:tiger_roads"}, names)
+	assert.Equal(t, []*Resource{{Name: "polygon"}, nil, {Name: "line"}}, layerGroup.Styles.Style)
+	assert.Equal(t, PublishableTypeLayerGroup, layerGroup.Publishables.Published[1].Type)
+
+	data, err := json.Marshal(layerGroup.Styles)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"style":[{"name":"polygon"},"",{"name":"line"}]}`, string(data))
+	var styles LayerGroupStyles
+	assert.Nil(t, json.Unmarshal(data, &styles))
+	assert.Equal(t, layerGroup.Styles, styles)
+	assert.Nil(t, json.Unmarshal([]byte(`{"style":{"name":"polygon"}}`), &styles))
+	assert.Equal(t, []*Resource{{Name: "polygon"}}, styles.Style)
+	assert.Nil(t, json.Unmarshal([]byte(`""`), &styles))
+	assert.Empty(t, styles.Style)
+}
+func TestLayerGroupXML(t *testing.T) {
+	layerGroup := LayerGroup{
+		Name:               "eo_group",
+		Mode:               LayerGroupModeEO,
+		Title:              "EO",
+		Abstract:           "EO group",
+		InternationalTitle: InternationalString{"en": "EO", "de": "EO Gruppe"},
+		Enabled:            BoolPtr(false),
+		Advertised:         BoolPtr(true),
+		RootLayer:          &Resource{Name: "tiger:giant_polygon"},
+		RootLayerStyle:     &Resource{Name: "polygon"},
+		Attribution:        &Attribution{Title: "Tiger"},
+		Bounds: NativeBoundingBox{
+			BoundingBox: BoundingBox{Minx: -74.047185, Maxx: -73.90782, Miny: 40.679648, Maxy: 40.882078},
+			Crs:         &CRSType{Class: "string", Value: "EPSG:4326"},
+		},
+	}
+	layerGroup.AddPublishable(NewLayerPublishable("tiger:poi"), nil)
+	layerGroup.AddPublishable(NewLayerGroupPublishable("tiger-ny"), &Resource{Name: "point"})
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	data, err := gsCatalog.SerializeToXML(layerGroup)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `<published type="layerGroup"><name>tiger-ny</name></published>`)
+	assert.Contains(t, string(data), `<styles><style></style><style><name>point</name></style></styles>`)
+	assert.Contains(t, string(data), `<internationalTitle><de>EO Gruppe</de><en>EO</en></internationalTitle>`)
+	assert.Contains(t, string(data), `<crs>EPSG:4326</crs>`)
+	var parsed LayerGroup
+	assert.Nil(t, xml.Unmarshal(data, &parsed))
+	parsed.XMLName = xml.Name{}
+	assert.Equal(t, layerGroup, parsed)
+
+	data, err = json.Marshal(layerGroupDetailsResponse{LayerGroup: &layerGroup})
+	assert.Nil(t, err)
+	var parsedJSON layerGroupDetailsResponse
+	assert.Nil(t, json.Unmarshal(data, &parsedJSON))
+	assert.Equal(t, layerGroup.RootLayer, parsedJSON.LayerGroup.RootLayer)
+	assert.Equal(t, layerGroup.InternationalTitle, parsedJSON.LayerGroup.InternationalTitle)
+	assert.False(t, *parsedJSON.LayerGroup.Enabled)
+
+	assert.NotNil(t, validateLayerGroup(&LayerGroup{Mode: LayerGroupModeEO}))
+	assert.Nil(t, validateLayerGroup(&layerGroup))
+}
+func TestCreateLayerGroupXML(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	layerGroup, err := gsCatalog.GetLayerGroupXML("", "tiger-ny")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, layerGroup.Publishables.Published)
+	layerGroup.Name = "tiger-ny-xml"
+	created, err := gsCatalog.CreateLayerGroupXML("", layerGroup)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	layerGroup.Advertised = BoolPtr(false)
+	modified, err := gsCatalog.UpdateLayerGroupXML("", "tiger-ny-xml", layerGroup)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	layerGroup, err = gsCatalog.GetLayerGroupXML("", "dummy_layer_group")
+	assert.Equal(t, layerGroup, &LayerGroup{})
+	assert.NotNil(t, err)
+}
+func TestUpdateLayerGroupPartial(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, putMethod, r.Method)
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+	layerGroup := &LayerGroup{Title: "Tiger NY", Styles: LayerGroupStyles{Style: []*Resource{{Name: "point"}}}}
+	modified, err := gsCatalog.UpdateLayerGroup("tiger", "tiger-ny", layerGroup)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, "Tiger NY", body["layerGroup"]["title"])
+	assert.NotContains(t, body["layerGroup"], "bounds")
+	assert.NotContains(t, body["layerGroup"], "publishables")
+	assert.NotContains(t, body["layerGroup"], "keywords")
+	assert.Len(t, layerGroup.Styles.Style, 1)
+
+	modified, err = gsCatalog.UpdateLayerGroup("tiger", "tiger-ny", &LayerGroup{Title: "Tiger NY"}, "abstractTxt")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Tiger NY", "abstractTxt": ""}, body["layerGroup"])
+}
+func TestLayerGroupUnmarshalErrors(t *testing.T) {
+	var styles LayerGroupStyles
+	assert.NotNil(t, json.Unmarshal([]byte(`{"style":42}`), &styles))
+	assert.NotNil(t, json.Unmarshal([]byte(`[]`), &styles))
+
+	var group layerGroupDetailsResponse
+	assert.Nil(t, json.Unmarshal([]byte(`{"layerGroup":{"bounds":{"minx":1,"miny":2,"maxx":3,"maxy":4,"crs":"EPSG:4326"}}}`), &group))
+	assert.Equal(t, &CRSType{Class: "string", Value: "EPSG:4326"}, group.LayerGroup.Bounds.Crs)
+}
+func TestUpdateLayerGroupBounds(t *testing.T) {
+	var body map[string]map[string]interface{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/workspaces/ws/layergroups/grp":
+			w.Write([]byte(`{"layerGroup":{"name":"grp","bounds":{"minx":0,"miny":0,"maxx":1,"maxy":1,"crs":"EPSG:3857"}}}`))
+		case "GET /rest/layers/ws:roads":
+			w.Write([]byte(`{"layer":{"name":"roads","resource":{"href":"` + server.URL + `/rest/workspaces/ws/datastores/pg/featuretypes/roads.json"}}}`))
+		case "GET /rest/workspaces/ws/datastores/pg/featuretypes/roads.json":
+			w.Write([]byte(`{"featureType":{"name":"roads","srs":"EPSG:3857",
+				"nativeBoundingBox":{"minx":100,"miny":200,"maxx":300,"maxy":400,"crs":"EPSG:3857"},
+				"latLonBoundingBox":{"minx":1,"miny":2,"maxx":3,"maxy":4,"crs":"EPSG:4326"}}}`))
+		case "PUT /rest/workspaces/ws/layergroups/grp":
+			body = nil
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	layerGroup := &LayerGroup{}
+	layerGroup.Publishables.Published = PublishedGroupLayers{NewLayerPublishable("roads")}
+	modified, err := gsCatalog.UpdateLayerGroup("ws", "grp", layerGroup)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"minx": 100.0, "miny": 200.0, "maxx": 300.0, "maxy": 400.0, "crs": "EPSG:3857"},
+		body["layerGroup"]["bounds"])
+	assert.Empty(t, layerGroup.Styles.Style)
+	assert.True(t, IsEmpty(layerGroup.Bounds))
+
+	layerGroup.Bounds.Crs = &CRSType{Class: "string", Value: "EPSG:4326"}
+	modified, err = gsCatalog.UpdateLayerGroup("ws", "grp", layerGroup)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"minx": 1.0, "miny": 2.0, "maxx": 3.0, "maxy": 4.0, "crs": "EPSG:4326"},
+		body["layerGroup"]["bounds"])
+
+	layerGroup.Bounds.Crs = &CRSType{Class: "string", Value: "EPSG:32633"}
+	modified, err = gsCatalog.UpdateLayerGroup("ws", "grp", layerGroup)
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+}
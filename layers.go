@@ -2,6 +2,7 @@ package geoserver
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -14,6 +15,7 @@ type LayerService interface {
 
 	//GetLayers  get all layers from workspace in geoserver else return error
 	GetLayers(workspaceName string) (layers []*Resource, err error)
+	IterateLayers(workspaceName string, options IteratorOptions) (*ResourceIterator, error)
 
 	// GetshpFiledsName datastore name from shapefile name
 	GetshpFiledsName(filename string) string
@@ -24,8 +26,8 @@ type LayerService interface {
 	//GetLayer get specific Layer from geoserver else return error
 	GetLayer(workspaceName string, layerName string) (layer *Layer, err error)
 
-	//UpdateLayer partial update geoserver layer else return error
-	UpdateLayer(workspaceName string, layerName string, layer Layer) (modified bool, err error)
+	//UpdateLayer partial update geoserver layer else return error, fields is an optional field mask
+	UpdateLayer(workspaceName string, layerName string, layer Layer, fields ...string) (modified bool, err error)
 
 	//DeleteLayer delete geoserver layer and its reources else return error
 	DeleteLayer(workspaceName string, layerName string, recurse bool) (deleted bool, err error)
@@ -33,39 +35,205 @@ type LayerService interface {
 	PublishPostgisLayer(workspaceName string, datastoreName string, publishName string, tableName string, attributes FeatureType) (published bool, err error)
 
 	PublishGeoTiffLayer(workspaceName string, coveragestoreName string, publishName string, fileName string) (published bool, err error)
+
+	//ListLayerStyles get the additional styles assigned to the layer else return error
+	ListLayerStyles(workspaceName string, layerName string) (styles []*Resource, err error)
+
+	//SetLayerDefaultStyle set the default style of the layer else return error
+	SetLayerDefaultStyle(workspaceName string, layerName string, styleName string) (modified bool, err error)
+
+	//AddLayerStyle add a style to the additional styles of the layer else return error
+	AddLayerStyle(workspaceName string, layerName string, styleName string) (modified bool, err error)
+
+	//RemoveLayerStyle remove a style from the additional styles of the layer else return error
+	RemoveLayerStyle(workspaceName string, layerName string, styleName string) (modified bool, err error)
 }
 
 // Resource geoserver resource
 type Resource struct {
-	Class string `json:"@class,omitempty"`
-	Name  string `json:"name,omitempty"`
-	Href  string `json:"href,omitempty"`
+	Class string `json:"@class,omitempty" xml:"class,attr,omitempty"`
+	Name  string `json:"name,omitempty" xml:"name,omitempty"`
+	Href  string `json:"href,omitempty" xml:"-"`
 }
 
 // Attribution of resource
 type Attribution struct {
-	Title      string `json:"title,omitempty"`
-	Href       string `json:"href,omitempty"`
-	LogoURL    string `json:"logoURL,omitempty"`
-	LogoType   string `json:"logoType,omitempty"`
-	LogoWidth  int    `json:"logoWidth,omitempty"`
-	LogoHeight int    `json:"logoHeight,omitempty"`
+	Title      string `json:"title,omitempty" xml:"title,omitempty"`
+	Href       string `json:"href,omitempty" xml:"href,omitempty"`
+	LogoURL    string `json:"logoURL,omitempty" xml:"logoURL,omitempty"`
+	LogoType   string `json:"logoType,omitempty" xml:"logoType,omitempty"`
+	LogoWidth  int    `json:"logoWidth,omitempty" xml:"logoWidth,omitempty"`
+	LogoHeight int    `json:"logoHeight,omitempty" xml:"logoHeight,omitempty"`
 }
 
+// LayerStyles holds the additional styles of the layer
+type LayerStyles struct {
+	Class string     `json:"@class,omitempty"`
+	Style []Resource `json:"style,omitempty"`
+}
+
+// UnmarshalJSON custom deserialization to handle a single style object and an empty styles list
+func (u *LayerStyles) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*u = LayerStyles{}
+	if _, ok := raw.(map[string]interface{}); !ok {
+		return nil
+	}
+	var styles struct {
+		Class string          `json:"@class,omitempty"`
+		Style json.RawMessage `json:"style,omitempty"`
+	}
+	if err = json.Unmarshal(data, &styles); err != nil {
+		return err
+	}
+	u.Class = styles.Class
+	if len(styles.Style) == 0 {
+		return nil
+	}
+	if styles.Style[0] == '{' {
+		var style Resource
+		if err = json.Unmarshal(styles.Style, &style); err != nil {
+			return err
+		}
+		u.Style = []Resource{style}
+		return nil
+	}
+	return json.Unmarshal(styles.Style, &u.Style)
+}
+
+// AuthorityURL is the authority publishing the layer identifiers
+type AuthorityURL struct {
+	Name string `json:"name,omitempty"`
+	Href string `json:"href,omitempty"`
+}
+
+// AuthorityURLs holds the layer authority urls
+type AuthorityURLs struct {
+	AuthorityURL []*AuthorityURL `json:"AuthorityURL,omitempty"`
+}
+
+// UnmarshalJSON custom deserialization to handle a single authority url object and an empty list
+func (u *AuthorityURLs) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AuthorityURL json.RawMessage `json:"AuthorityURL,omitempty"`
+	}
+	*u = AuthorityURLs{}
+	if isEmptyJSONList(data) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalJSONList(raw.AuthorityURL, &u.AuthorityURL)
+}
+
+// LayerIdentifier is the layer identifier defined by the authority
+type LayerIdentifier struct {
+	Authority  string `json:"authority,omitempty"`
+	Identifier string `json:"identifier,omitempty"`
+}
+
+// LayerIdentifiers holds the layer identifiers
+type LayerIdentifiers struct {
+	Identifier []*LayerIdentifier `json:"Identifier,omitempty"`
+}
+
+// UnmarshalJSON custom deserialization to handle a single identifier object and an empty list
+func (u *LayerIdentifiers) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Identifier json.RawMessage `json:"Identifier,omitempty"`
+	}
+	*u = LayerIdentifiers{}
+	if isEmptyJSONList(data) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalJSONList(raw.Identifier, &u.Identifier)
+}
+
+// isEmptyJSONList returns true for "" and null geoserver returns for the empty list
+func isEmptyJSONList(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return bytes.Equal(data, []byte(`""`)) || bytes.Equal(data, []byte("null"))
+}
+
+// unmarshalJSONList decodes the json array to the slice pointed by list,
+// geoserver writes a single item list as the bare object and the empty list as ""
+func unmarshalJSONList(data json.RawMessage, list interface{}) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || isEmptyJSONList(data) {
+		return nil
+	}
+	if data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	return json.Unmarshal(data, list)
+}
+
+// LegendInfo is the external legend graphic of the layer
+type LegendInfo struct {
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	Format         string `json:"format,omitempty"`
+	OnlineResource string `json:"onlineResource,omitempty"`
+}
+
+const (
+	InterpolationNearest  = "Nearest"  //nearest neighbor WMS interpolation method
+	InterpolationBilinear = "Bilinear" //bilinear WMS interpolation method
+	InterpolationBicubic  = "Bicubic"  //bicubic WMS interpolation method
+
+	layerCachingEnabledKey = "cachingEnabled"
+	layerCacheAgeMaxKey    = "cacheAgeMax"
+)
+
 // Layer geoserver layers
 type Layer struct {
-	Name         string    `json:"name,omitempty"`
-	Path         string    `json:"path,omitempty"`
-	Type         string    `json:"type,omitempty"`
-	DefaultStyle *Resource `json:"defaultStyle,omitempty"`
-	Styles       *struct {
-		Class string     `json:"@class,omitempty"`
-		Style []Resource `json:"style,omitempty"`
-	} `json:"styles,omitempty"`
-	Resource    Resource     `json:"resource,omitempty"`
-	Queryable   bool         `json:"queryable,omitempty"`
-	Opaque      bool         `json:"opaque,omitempty"`
-	Attribution *Attribution `json:"attribution,omitempty"`
+	Name                          string            `json:"name,omitempty"`
+	Path                          string            `json:"path,omitempty"`
+	Type                          string            `json:"type,omitempty"`
+	DefaultStyle                  *Resource         `json:"defaultStyle,omitempty"`
+	Styles                        *LayerStyles      `json:"styles,omitempty"`
+	Resource                      Resource          `json:"resource,omitempty"`
+	Queryable                     bool              `json:"queryable,omitempty"`
+	Opaque                        bool              `json:"opaque,omitempty"`
+	Attribution                   *Attribution      `json:"attribution,omitempty"`
+	Enabled                       *bool             `json:"enabled,omitempty"`
+	Advertised                    *bool             `json:"advertised,omitempty"`
+	AuthorityURLs                 *AuthorityURLs    `json:"authorityURLs,omitempty"`
+	Identifiers                   *LayerIdentifiers `json:"identifiers,omitempty"`
+	Metadata                      *Metadata         `json:"metadata,omitempty"`
+	DefaultWMSInterpolationMethod string            `json:"defaultWMSInterpolationMethod,omitempty"`
+	Legend                        *LegendInfo       `json:"legend,omitempty"`
+}
+
+// SetCaching sets the layer http caching headers, maxAge is the cache max age in seconds
+func (l *Layer) SetCaching(enabled bool, maxAge int) {
+	if l.Metadata == nil {
+		l.Metadata = &Metadata{}
+	}
+	l.Metadata.Set(layerCachingEnabledKey, strconv.FormatBool(enabled))
+	l.Metadata.Set(layerCacheAgeMaxKey, strconv.Itoa(maxAge))
+}
+
+// Caching returns the layer http caching headers settings, maxAge is the cache max age in seconds
+func (l Layer) Caching() (enabled bool, maxAge int) {
+	if l.Metadata == nil {
+		return
+	}
+	if value, ok := l.Metadata.Get(layerCachingEnabledKey); ok {
+		enabled, _ = strconv.ParseBool(value)
+	}
+	if value, ok := l.Metadata.Get(layerCacheAgeMaxKey); ok {
+		maxAge, _ = strconv.Atoi(value)
+	}
+	return
 }
 
 // LayerRequestBody api json
@@ -181,32 +349,27 @@ func (g *GeoServer) GetLayer(workspaceName string, layerName string) (layer *Lay
 }
 
 // UpdateLayer partial update geoserver layer else return error,
+// fields is an optional field mask, a list of layer json field names to send even if they are false, 0 or "" (e.g. "queryable"),
+// without fields only the fields set in the layer are sent, the others are left untouched on the server,
 // if workspace is "" the it will update  public layer with name ${layerName} in geoserver
-func (g *GeoServer) UpdateLayer(workspaceName string, layerName string, layer Layer) (modified bool, err error) {
+func (g *GeoServer) UpdateLayer(workspaceName string, layerName string, layer Layer, fields ...string) (modified bool, err error) {
 	if workspaceName != "" {
 		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
 	}
 	targetURL := g.ParseURL("rest", workspaceName, "layers", layerName)
-	data := LayerRequestBody{Layer: layer}
-
-	serializedLayer, _ := g.SerializeStruct(data)
-	httpRequest := HTTPRequest{
-		Method:   putMethod,
-		Accept:   jsonType,
-		Data:     bytes.NewBuffer(serializedLayer),
-		DataType: jsonType,
-		URL:      targetURL,
-		Query:    nil,
-	}
-	response, responseCode := g.DoRequest(httpRequest)
-	if responseCode != statusOk {
-		g.logger.Error(string(response))
-		modified = false
-		err = g.GetError(responseCode, response)
-		return
+	patch, err := patchEntity(layer, fields)
+	if err != nil {
+		return false, err
 	}
-	modified = true
-	return
+	data := map[string]interface{}{"layer": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
 }
 
 // PublishPostgisLayer publish postgis table to geoserver
@@ -264,3 +427,74 @@ func (g *GeoServer) DeleteLayer(workspaceName string, layerName string, recurse
 	deleted = true
 	return
 }
+
+// ListLayerStyles returns the additional styles assigned to the layer,
+// if workspace is "" the layerName is used as is (it can be qualified as ${workspace}:${layer})
+func (g *GeoServer) ListLayerStyles(workspaceName string, layerName string) (styles []*Resource, err error) {
+	if workspaceName != "" {
+		layerName = fmt.Sprintf("%s:%s", workspaceName, layerName)
+	}
+	targetURL := g.ParseURL("rest", "layers", layerName, "styles")
+
+	var stylesResponse struct {
+		Styles LayerStyles `json:"styles,omitempty"`
+	}
+	if err = g.requestResource(targetURL, &stylesResponse); err != nil {
+		return nil, err
+	}
+
+	styles = make([]*Resource, 0, len(stylesResponse.Styles.Style))
+	for i := range stylesResponse.Styles.Style {
+		styles = append(styles, &stylesResponse.Styles.Style[i])
+	}
+	return
+}
+
+// SetLayerDefaultStyle sets the default style of the layer,
+// styleName can be qualified with the style workspace as ${workspace}:${style}
+func (g *GeoServer) SetLayerDefaultStyle(workspaceName string, layerName string, styleName string) (modified bool, err error) {
+	return g.UpdateLayer(workspaceName, layerName, Layer{DefaultStyle: &Resource{Name: styleName}})
+}
+
+// AddLayerStyle adds a style to the additional styles of the layer, it does nothing if the style is already assigned,
+// styleName is qualified with the style workspace as ${workspace}:${style} for the workspace styles
+func (g *GeoServer) AddLayerStyle(workspaceName string, layerName string, styleName string) (modified bool, err error) {
+	layer, err := g.GetLayer(workspaceName, layerName)
+	if err != nil {
+		return false, err
+	}
+	styles := &LayerStyles{}
+	if layer.Styles != nil {
+		styles = layer.Styles
+	}
+	for _, s := range styles.Style {
+		if s.Name == styleName {
+			return false, nil
+		}
+	}
+	styles.Style = append(styles.Style, Resource{Name: styleName})
+	return g.UpdateLayer(workspaceName, layerName, Layer{Styles: styles})
+}
+
+// RemoveLayerStyle removes a style from the additional styles of the layer, it does nothing if the style isn't assigned,
+// styleName is qualified with the style workspace as ${workspace}:${style} for the workspace styles
+func (g *GeoServer) RemoveLayerStyle(workspaceName string, layerName string, styleName string) (modified bool, err error) {
+	layer, err := g.GetLayer(workspaceName, layerName)
+	if err != nil {
+		return false, err
+	}
+	if layer.Styles == nil {
+		return false, nil
+	}
+	styles := &LayerStyles{Class: layer.Styles.Class, Style: make([]Resource, 0, len(layer.Styles.Style))}
+	for _, s := range layer.Styles.Style {
+		if s.Name != styleName {
+			styles.Style = append(styles.Style, s)
+		}
+	}
+	if len(styles.Style) == len(layer.Styles.Style) {
+		return false, nil
+	}
+	// the styles are sent even if the last style is removed
+	return g.UpdateLayer(workspaceName, layerName, Layer{Styles: styles}, "styles")
+}
@@ -2,6 +2,8 @@ package geoserver
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -51,6 +53,51 @@ func TestUpdateLayer(t *testing.T) {
 	assert.False(t, modified)
 	assert.NotNil(t, err)
 }
+func TestUpdateLayerAdvertised(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	layer := Layer{Advertised: BoolPtr(false)}
+	layer.SetCaching(true, 3600)
+	modified, err := gsCatalog.UpdateLayer("topp", "tasmania_cities", layer)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	updated, err := gsCatalog.GetLayer("topp", "tasmania_cities")
+	assert.Nil(t, err)
+	assert.False(t, *updated.Advertised)
+	assert.NotNil(t, updated.DefaultStyle)
+	enabled, maxAge := updated.Caching()
+	assert.True(t, enabled)
+	assert.Equal(t, 3600, maxAge)
+	modified, err = gsCatalog.UpdateLayer("topp", "tasmania_cities", Layer{Advertised: BoolPtr(true)})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+}
+func TestLayerModel(t *testing.T) {
+	data := []byte(`{"layer":{"name":"tasmania_cities","type":"VECTOR","enabled":true,"advertised":false,
+		"authorityURLs":{"AuthorityURL":[{"name":"golang","href":"http://golang.org"}]},
+		"identifiers":{"Identifier":[{"authority":"golang","identifier":"cities"}]},
+		"metadata":{"entry":{"@key":"cachingEnabled","$":"true"}},
+		"defaultWMSInterpolationMethod":"Bilinear",
+		"legend":{"width":20,"height":20,"format":"image/png","onlineResource":"http://golang.org/legend.png"}}}`)
+	var body LayerRequestBody
+	assert.Nil(t, json.Unmarshal(data, &body))
+	layer := body.Layer
+	assert.True(t, *layer.Enabled)
+	assert.False(t, *layer.Advertised)
+	assert.Equal(t, "http://golang.org", layer.AuthorityURLs.AuthorityURL[0].Href)
+	assert.Equal(t, "cities", layer.Identifiers.Identifier[0].Identifier)
+	assert.Equal(t, InterpolationBilinear, layer.DefaultWMSInterpolationMethod)
+	assert.Equal(t, 20, layer.Legend.Width)
+	enabled, maxAge := layer.Caching()
+	assert.True(t, enabled)
+	assert.Equal(t, 0, maxAge)
+	layer.SetCaching(false, 60)
+	assert.Len(t, layer.Metadata.Entry, 2)
+
+	compacted, err := compactEntity(LayerRequestBody{Layer: Layer{Advertised: BoolPtr(false)}})
+	assert.Nil(t, err)
+	data, _ = json.Marshal(compacted)
+	assert.Equal(t, `{"layer":{"advertised":false}}`, string(data))
+}
 func TestPublishPostgisLayer(t *testing.T) {
 	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
 	conn := DatastoreConnection{
@@ -104,6 +151,101 @@ func TestDeleteLayer(t *testing.T) {
 	assert.False(t, deleted)
 	assert.NotNil(t, err)
 }
+func TestLayerStyles(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	modified, err := gsCatalog.AddLayerStyle("topp", "tasmania_cities", "capitals")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	styles, err := gsCatalog.ListLayerStyles("topp", "tasmania_cities")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, styles)
+	modified, err = gsCatalog.AddLayerStyle("topp", "tasmania_cities", "capitals")
+	assert.False(t, modified)
+	assert.Nil(t, err)
+	modified, err = gsCatalog.SetLayerDefaultStyle("topp", "tasmania_cities", "capitals")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	modified, err = gsCatalog.RemoveLayerStyle("topp", "tasmania_cities", "capitals")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	modified, err = gsCatalog.AddLayerStyle("topp_dummy", "tasmania_cities", "capitals")
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+	styles, err = gsCatalog.ListLayerStyles("topp_dummy", "tasmania_cities")
+	assert.Nil(t, styles)
+	assert.NotNil(t, err)
+}
+func TestRemoveLayerStyle(t *testing.T) {
+	var updates []map[string]map[string]interface{}
+	styles := `[{"name":"ws:line"},{"name":"other:line"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"layer":{"name":"roads","styles":{"@class":"linked-hash-set","style":` + styles + `}}}`))
+		case http.MethodPut:
+			var body map[string]map[string]interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			updates = append(updates, body)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	// the unqualified name references the global style
+	modified, err := gsCatalog.RemoveLayerStyle("ws", "roads", "line")
+	assert.False(t, modified)
+	assert.Nil(t, err)
+	assert.Empty(t, updates)
+
+	modified, err = gsCatalog.RemoveLayerStyle("ws", "roads", "other:line")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Len(t, updates, 1)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "ws:line"}}, updates[0]["layer"]["styles"].(map[string]interface{})["style"])
+
+	modified, err = gsCatalog.AddLayerStyle("ws", "roads", "line")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Len(t, updates[1]["layer"]["styles"].(map[string]interface{})["style"], 3)
+
+	// removing the last style sends the empty styles
+	styles = `{"name":"ws:line"}`
+	modified, err = gsCatalog.RemoveLayerStyle("ws", "roads", "ws:line")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"@class": "linked-hash-set"}, updates[2]["layer"]["styles"])
+}
+
+func TestLayerStylesUnmarshalJSON(t *testing.T) {
+	var layer Layer
+	err := json.Unmarshal([]byte(`{"name":"l","styles":{"@class":"linked-hash-set","style":{"name":"ws:s1","href":"http://localhost/s1.json"}}}`), &layer)
+	assert.Nil(t, err)
+	assert.Equal(t, []Resource{{Name: "ws:s1", Href: "http://localhost/s1.json"}}, layer.Styles.Style)
+	err = json.Unmarshal([]byte(`{"name":"l","styles":{"style":[{"name":"s1"},{"name":"s2"}]}}`), &layer)
+	assert.Nil(t, err)
+	assert.Len(t, layer.Styles.Style, 2)
+	err = json.Unmarshal([]byte(`{"name":"l","styles":""}`), &layer)
+	assert.Nil(t, err)
+	assert.Empty(t, layer.Styles.Style)
+}
+func TestLayerAuthorityUnmarshalJSON(t *testing.T) {
+	var layer Layer
+	err := json.Unmarshal([]byte(`{"name":"l","authorityURLs":{"AuthorityURL":{"name":"golang","href":"http://golang.org"}},
+		"identifiers":{"Identifier":{"authority":"golang","identifier":"cities"}}}`), &layer)
+	assert.Nil(t, err)
+	assert.Equal(t, []*AuthorityURL{{Name: "golang", Href: "http://golang.org"}}, layer.AuthorityURLs.AuthorityURL)
+	assert.Equal(t, []*LayerIdentifier{{Authority: "golang", Identifier: "cities"}}, layer.Identifiers.Identifier)
+	err = json.Unmarshal([]byte(`{"name":"l","authorityURLs":"","identifiers":""}`), &layer)
+	assert.Nil(t, err)
+	assert.Empty(t, layer.AuthorityURLs.AuthorityURL)
+	assert.Empty(t, layer.Identifiers.Identifier)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"name":"l","identifiers":{"Identifier":"cities"}}`), &layer))
+
+	err = json.Unmarshal([]byte(`{"name":"l","metadata":""}`), &layer)
+	assert.Nil(t, err)
+	assert.Empty(t, layer.Metadata.Entry)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"name":"l","metadata":42}`), &layer))
+}
 func TestGeoserverImplemetLayerService(t *testing.T) {
 	gsCatalog := reflect.TypeOf(&GeoServer{})
 	LayerServiceType := reflect.TypeOf((*LayerService)(nil)).Elem()
@@ -0,0 +1,247 @@
+package geoserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Manifest is the declarative description of geoserver catalog objects,
+// Styles are the global styles, ACL rules are layer security rules,
+// if Prune is true the objects of the manifest workspaces which aren't described in the manifest are deleted,
+// global styles, workspaces not listed in the manifest and the layers not published from a datastore are never deleted
+type Manifest struct {
+	Styles     []ManifestStyle     `yaml:"styles,omitempty" json:"styles,omitempty"`
+	Workspaces []ManifestWorkspace `yaml:"workspaces,omitempty" json:"workspaces,omitempty"`
+	ACL        []ManifestACLRule   `yaml:"acl,omitempty" json:"acl,omitempty"`
+	Prune      bool                `yaml:"prune,omitempty" json:"prune,omitempty"`
+	baseDir    string
+}
+
+// ManifestWorkspace describes the workspace and its content
+type ManifestWorkspace struct {
+	Name        string               `yaml:"name" json:"name"`
+	Isolated    bool                 `yaml:"isolated,omitempty" json:"isolated,omitempty"`
+	Datastores  []ManifestDatastore  `yaml:"datastores,omitempty" json:"datastores,omitempty"`
+	Styles      []ManifestStyle      `yaml:"styles,omitempty" json:"styles,omitempty"`
+	Layers      []ManifestLayer      `yaml:"layers,omitempty" json:"layers,omitempty"`
+	LayerGroups []ManifestLayerGroup `yaml:"layerGroups,omitempty" json:"layerGroups,omitempty"`
+}
+
+// ManifestDatastore describes the datastore, Connection contains the datastore connection parameters
+// like host, port, database, user, passwd, dbtype, the password parameters aren't compared with the server
+type ManifestDatastore struct {
+	Name       string            `yaml:"name" json:"name"`
+	Enabled    *bool             `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Connection map[string]string `yaml:"connection,omitempty" json:"connection,omitempty"`
+}
+
+// ManifestStyle describes the style, the style definition (SLD) is given inline in Body
+// or as File path relative to the manifest file
+type ManifestStyle struct {
+	Name string `yaml:"name" json:"name"`
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// ManifestLayer describes the layer published from the datastore table (feature type),
+// NativeName is the table name, Name is used if it's empty,
+// style names can be qualified as ${workspace}:${style}, unqualified names of the workspace styles are qualified automatically
+type ManifestLayer struct {
+	Name         string       `yaml:"name" json:"name"`
+	Datastore    string       `yaml:"datastore" json:"datastore"`
+	NativeName   string       `yaml:"nativeName,omitempty" json:"nativeName,omitempty"`
+	Title        string       `yaml:"title,omitempty" json:"title,omitempty"`
+	Abstract     string       `yaml:"abstract,omitempty" json:"abstract,omitempty"`
+	Srs          string       `yaml:"srs,omitempty" json:"srs,omitempty"`
+	Enabled      *bool        `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	DefaultStyle string       `yaml:"defaultStyle,omitempty" json:"defaultStyle,omitempty"`
+	Styles       []string     `yaml:"styles,omitempty" json:"styles,omitempty"`
+	GWC          *ManifestGWC `yaml:"gwc,omitempty" json:"gwc,omitempty"`
+}
+
+// ManifestGWC describes GeoWebCache caching of the layer,
+// MetaTiling is the metatile width and height, ExpireCache is in seconds
+type ManifestGWC struct {
+	Enabled     *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	GridSets    []string `yaml:"gridSets,omitempty" json:"gridSets,omitempty"`
+	Formats     []string `yaml:"formats,omitempty" json:"formats,omitempty"`
+	MetaTiling  []int    `yaml:"metaTiling,omitempty" json:"metaTiling,omitempty"`
+	ExpireCache int      `yaml:"expireCache,omitempty" json:"expireCache,omitempty"`
+}
+
+// ManifestLayerGroup describes the layergroup, Layers are the names of the layers or the layergroups of the workspace
+// (can be qualified as ${workspace}:${name}), Styles are the styles of the Layers with the same index, "" means the default style
+type ManifestLayerGroup struct {
+	Name     string   `yaml:"name" json:"name"`
+	Mode     string   `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Title    string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Abstract string   `yaml:"abstract,omitempty" json:"abstract,omitempty"`
+	Layers   []string `yaml:"layers" json:"layers"`
+	Styles   []string `yaml:"styles,omitempty" json:"styles,omitempty"`
+}
+
+// ManifestACLRule describes the layer security rule, see AclRule
+type ManifestACLRule struct {
+	Workspace string   `yaml:"workspace" json:"workspace"`
+	Layer     string   `yaml:"layer" json:"layer"`
+	Operation string   `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Roles     []string `yaml:"roles,omitempty" json:"roles,omitempty"`
+}
+
+// LoadManifest loads the manifest from yaml file, style files are resolved relative to the manifest file
+func LoadManifest(manifestFile string) (manifest *Manifest, err error) {
+	data, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(data, filepath.Dir(manifestFile))
+}
+
+// ParseManifest parses yaml manifest, style files are resolved relative to the baseDir
+func ParseManifest(data []byte, baseDir string) (manifest *Manifest, err error) {
+	manifest = &Manifest{}
+	if err = yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("can't parse manifest: %v", err)
+	}
+	manifest.baseDir = baseDir
+	if err = manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Validate checks the manifest for missing names, duplicates and references to undefined datastores
+func (m *Manifest) Validate() error {
+	if err := validateManifestStyles("", m.Styles); err != nil {
+		return err
+	}
+	workspaces := make(map[string]bool)
+	for _, ws := range m.Workspaces {
+		if ws.Name == "" {
+			return fmt.Errorf("manifest workspace name is empty")
+		}
+		if workspaces[ws.Name] {
+			return fmt.Errorf("workspace %s is defined twice in the manifest", ws.Name)
+		}
+		workspaces[ws.Name] = true
+		if err := validateManifestStyles(ws.Name, ws.Styles); err != nil {
+			return err
+		}
+		datastores := make(map[string]bool)
+		for _, ds := range ws.Datastores {
+			if ds.Name == "" || datastores[ds.Name] {
+				return fmt.Errorf("datastore name %q in workspace %s is empty or defined twice", ds.Name, ws.Name)
+			}
+			datastores[ds.Name] = true
+		}
+		names := make(map[string]bool)
+		for _, l := range ws.Layers {
+			if l.Name == "" || names[l.Name] {
+				return fmt.Errorf("layer name %q in workspace %s is empty or defined twice", l.Name, ws.Name)
+			}
+			if !datastores[l.Datastore] {
+				return fmt.Errorf("layer %s:%s references datastore %q which isn't defined in the manifest", ws.Name, l.Name, l.Datastore)
+			}
+			names[l.Name] = true
+		}
+		for _, lg := range ws.LayerGroups {
+			if lg.Name == "" || names[lg.Name] {
+				return fmt.Errorf("layergroup name %q in workspace %s is empty or already used", lg.Name, ws.Name)
+			}
+			if lg.Mode != "" && !layerGroupModes[lg.Mode] {
+				return fmt.Errorf("layergroup %s:%s has unknown mode %s", ws.Name, lg.Name, lg.Mode)
+			}
+			if len(lg.Styles) != 0 && len(lg.Styles) != len(lg.Layers) {
+				return fmt.Errorf("layergroup %s:%s styles don't match its layers", ws.Name, lg.Name)
+			}
+			names[lg.Name] = true
+		}
+	}
+	for _, r := range m.ACL {
+		if r.Workspace == "" || r.Layer == "" {
+			return fmt.Errorf("acl rule %v has empty workspace or layer, use * for any", r)
+		}
+	}
+	return nil
+}
+
+func validateManifestStyles(workspaceName string, styles []ManifestStyle) error {
+	names := make(map[string]bool)
+	for _, s := range styles {
+		if s.Name == "" || names[s.Name] {
+			return fmt.Errorf("style name %q in %q is empty or defined twice", s.Name, workspaceName)
+		}
+		if s.File == "" && s.Body == "" {
+			return fmt.Errorf("style %s has neither file nor body", qualifiedStyleName(workspaceName, s.Name))
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+// Marshal serializes the manifest to yaml
+func (m *Manifest) Marshal() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// styleBody returns the style definition from Body or from the File
+func (m *Manifest) styleBody(style ManifestStyle) ([]byte, error) {
+	if style.Body != "" {
+		return []byte(style.Body), nil
+	}
+	file := style.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(m.baseDir, file)
+	}
+	return ioutil.ReadFile(file)
+}
+
+// GetDatastoreObj return datastore Object to send to geoserver rest
+func (d ManifestDatastore) GetDatastoreObj() (datastore Datastore) {
+	datastore = Datastore{Name: d.Name, Enabled: d.Enabled == nil || *d.Enabled}
+	for _, key := range sortedKeys(d.Connection) {
+		datastore.ConnectionParameters.Entry = append(datastore.ConnectionParameters.Entry, &Entry{Key: key, Value: d.Connection[key]})
+	}
+	return
+}
+
+// AclRule converts the manifest rule to AclRule
+func (r ManifestACLRule) AclRule() AclRule {
+	return AclRule{Workspace: r.Workspace, Layer: r.Layer, Operation: aclOperation(r.Operation), Roles: r.Roles}
+}
+
+// qualifyName qualifies the name with the workspace if it isn't qualified yet
+func qualifyName(workspaceName string, name string) string {
+	if name == "" || strings.Contains(name, ":") {
+		return name
+	}
+	return fmt.Sprintf("%s:%s", workspaceName, name)
+}
+
+// qualifyStyle qualifies the style name with the workspace if the workspace defines the style
+func (w ManifestWorkspace) qualifyStyle(styleName string) string {
+	for _, s := range w.Styles {
+		if s.Name == styleName {
+			return qualifyName(w.Name, styleName)
+		}
+	}
+	return styleName
+}
+
+// isLayerGroup returns true if the name references the layergroup of the workspace
+func (w ManifestWorkspace) isLayerGroup(name string) bool {
+	workspaceName, groupName := splitQualifiedName(name)
+	if workspaceName != "" && workspaceName != w.Name {
+		return false
+	}
+	for _, lg := range w.LayerGroups {
+		if lg.Name == groupName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,736 @@
+package geoserver
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+const (
+	KindWorkspace  = "workspace"
+	KindStyle      = "style"
+	KindDatastore  = "datastore"
+	KindLayer      = "layer"
+	KindGWCLayer   = "gwcLayer"
+	KindLayerGroup = "layerGroup"
+	KindACLRule    = "aclRule"
+)
+
+// manifestKinds is the dependency order of the catalog objects, objects are created and updated in this order
+// and deleted in the reverse one
+var manifestKinds = []string{KindWorkspace, KindStyle, KindDatastore, KindLayer, KindGWCLayer, KindLayerGroup, KindACLRule}
+
+// ManifestChange is a single change of the plan, Action is one of Change* constants, Kind is one of Kind* constants,
+// Fields lists the changed fields of the updated object
+type ManifestChange struct {
+	Action    string
+	Kind      string
+	Workspace string
+	Name      string
+	Fields    []string
+	apply     func(g *GeoServer) error
+}
+
+func (c ManifestChange) String() string {
+	sign := map[string]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeDelete: "-"}[c.Action]
+	name := c.Name
+	if c.Workspace != "" && c.Kind != KindWorkspace && c.Kind != KindACLRule {
+		name = qualifiedStyleName(c.Workspace, c.Name)
+	}
+	if len(c.Fields) != 0 {
+		return fmt.Sprintf("%s %s %s (%s)", sign, c.Kind, name, strings.Join(c.Fields, ", "))
+	}
+	return fmt.Sprintf("%s %s %s", sign, c.Kind, name)
+}
+
+// ManifestPlan is the list of changes bringing the server to the state described by the manifest,
+// the changes are ordered by the objects dependencies
+type ManifestPlan struct {
+	Changes []ManifestChange
+}
+
+// Empty returns true if the server matches the manifest
+func (p *ManifestPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *ManifestPlan) String() string {
+	lines := make([]string, 0, len(p.Changes))
+	for _, c := range p.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// manifestPlanner collects the changes grouped by the kind
+type manifestPlanner struct {
+	g        *GeoServer
+	manifest *Manifest
+	upserts  map[string][]ManifestChange
+	deletes  map[string][]ManifestChange
+}
+
+func (p *manifestPlanner) add(change ManifestChange) {
+	if change.Action == ChangeDelete {
+		p.deletes[change.Kind] = append(p.deletes[change.Kind], change)
+	} else {
+		p.upserts[change.Kind] = append(p.upserts[change.Kind], change)
+	}
+}
+
+// Plan computes the changes required to bring the server to the state described by the manifest,
+// the server isn't modified, err is an error if error occurred else err is nil
+func (g *GeoServer) Plan(manifest *Manifest) (plan *ManifestPlan, err error) {
+	if err = manifest.Validate(); err != nil {
+		return nil, err
+	}
+	p := &manifestPlanner{g: g, manifest: manifest, upserts: map[string][]ManifestChange{}, deletes: map[string][]ManifestChange{}}
+
+	if err = p.planStyles("", manifest.Styles, true); err != nil {
+		return nil, err
+	}
+	for _, ws := range manifest.Workspaces {
+		if err = p.planWorkspace(ws); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.planACL(); err != nil {
+		return nil, err
+	}
+
+	plan = &ManifestPlan{Changes: []ManifestChange{}}
+	for _, kind := range manifestKinds {
+		plan.Changes = append(plan.Changes, p.upserts[kind]...)
+	}
+	for i := len(manifestKinds) - 1; i >= 0; i-- {
+		plan.Changes = append(plan.Changes, p.deletes[manifestKinds[i]]...)
+	}
+	return plan, nil
+}
+
+// Apply executes the plan changes in order, it stops on the first failed change,
+// applied is the number of the changes successfully applied
+func (g *GeoServer) Apply(plan *ManifestPlan) (applied int, err error) {
+	for _, c := range plan.Changes {
+		if err = c.apply(g); err != nil {
+			return applied, fmt.Errorf("can't %s %s %s: %v", c.Action, c.Kind, c.Name, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+func (p *manifestPlanner) planWorkspace(ws ManifestWorkspace) error {
+	live, err := p.g.GetWorkspace(ws.Name)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+	exists := err == nil
+	if !exists {
+		p.add(ManifestChange{Action: ChangeCreate, Kind: KindWorkspace, Name: ws.Name, apply: func(g *GeoServer) error {
+			if _, err := g.CreateWorkspace(ws.Name); err != nil {
+				return err
+			}
+			if ws.Isolated {
+				_, err := g.UpdateWorkspace(ws.Name, Workspace{Isolated: true}, "isolated")
+				return err
+			}
+			return nil
+		}})
+	} else if live.Isolated != ws.Isolated {
+		p.add(ManifestChange{Action: ChangeUpdate, Kind: KindWorkspace, Name: ws.Name, Fields: []string{"isolated"}, apply: func(g *GeoServer) error {
+			_, err := g.UpdateWorkspace(ws.Name, Workspace{Isolated: ws.Isolated}, "isolated")
+			return err
+		}})
+	}
+
+	if err = p.planStyles(ws.Name, ws.Styles, exists); err != nil {
+		return err
+	}
+	if err = p.planDatastores(ws, exists); err != nil {
+		return err
+	}
+	if err = p.planLayers(ws, exists); err != nil {
+		return err
+	}
+	return p.planLayerGroups(ws, exists)
+}
+
+// liveNames returns the set of unqualified names of the resources
+func liveNames(resources []*Resource) map[string]bool {
+	names := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		_, name := splitQualifiedName(r.Name)
+		names[name] = true
+	}
+	return names
+}
+
+func (p *manifestPlanner) planStyles(workspaceName string, styles []ManifestStyle, exists bool) error {
+	live := map[string]bool{}
+	if exists {
+		liveStyles, err := p.g.GetStyles(workspaceName)
+		if err != nil {
+			return err
+		}
+		live = liveNames(liveStyles)
+	}
+	defined := make(map[string]bool, len(styles))
+	for _, s := range styles {
+		s := s
+		defined[s.Name] = true
+		body, err := p.manifest.styleBody(s)
+		if err != nil {
+			return err
+		}
+		change := ManifestChange{Kind: KindStyle, Workspace: workspaceName, Name: s.Name, apply: func(g *GeoServer) error {
+			_, err := g.UploadStyle(bytes.NewReader(body), workspaceName, s.Name, true)
+			return err
+		}}
+		if !live[s.Name] {
+			change.Action = ChangeCreate
+			p.add(change)
+			continue
+		}
+		liveBody, err := p.g.GetStyleBody(workspaceName, s.Name)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bytes.TrimSpace(liveBody), bytes.TrimSpace(body)) {
+			change.Action = ChangeUpdate
+			change.Fields = []string{"body"}
+			p.add(change)
+		}
+	}
+	if workspaceName == "" || !p.manifest.Prune {
+		return nil
+	}
+	for name := range live {
+		if !defined[name] {
+			name := name
+			p.add(ManifestChange{Action: ChangeDelete, Kind: KindStyle, Workspace: workspaceName, Name: name, apply: func(g *GeoServer) error {
+				_, err := g.DeleteStyle(workspaceName, name, true)
+				return err
+			}})
+		}
+	}
+	return nil
+}
+
+// isPasswordParameter returns true for the connection parameters geoserver returns encrypted
+func isPasswordParameter(key string) bool {
+	key = strings.ToLower(key)
+	return key == "passwd" || key == "password"
+}
+
+func (p *manifestPlanner) planDatastores(ws ManifestWorkspace, exists bool) error {
+	live := map[string]bool{}
+	if exists {
+		liveDatastores, err := p.g.GetDatastores(ws.Name)
+		if err != nil {
+			return err
+		}
+		live = liveNames(liveDatastores)
+	}
+	defined := make(map[string]bool, len(ws.Datastores))
+	for _, ds := range ws.Datastores {
+		ds := ds
+		defined[ds.Name] = true
+		if !live[ds.Name] {
+			p.add(ManifestChange{Action: ChangeCreate, Kind: KindDatastore, Workspace: ws.Name, Name: ds.Name, apply: func(g *GeoServer) error {
+				_, err := g.CreateDatastore(ds, ws.Name)
+				return err
+			}})
+			continue
+		}
+		liveDatastore, err := p.g.GetDatastoreDetails(ws.Name, ds.Name)
+		if err != nil {
+			return err
+		}
+		var fields []string
+		if ds.Enabled != nil && *ds.Enabled != liveDatastore.Enabled {
+			fields = append(fields, "enabled")
+		}
+		parameters := make(map[string]string, len(liveDatastore.ConnectionParameters.Entry))
+		for _, e := range liveDatastore.ConnectionParameters.Entry {
+			parameters[e.Key] = e.Value
+		}
+		for key, value := range ds.Connection {
+			if !isPasswordParameter(key) && parameters[key] != value {
+				fields = append(fields, "connectionParameters")
+				break
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		for key, value := range ds.Connection {
+			parameters[key] = value
+		}
+		// the datastore state is changed only when the manifest sets it
+		update := Datastore{Enabled: liveDatastore.Enabled}
+		if ds.Enabled != nil {
+			update.Enabled = *ds.Enabled
+		}
+		for _, key := range sortedKeys(parameters) {
+			update.ConnectionParameters.Entry = append(update.ConnectionParameters.Entry, &Entry{Key: key, Value: parameters[key]})
+		}
+		p.add(ManifestChange{Action: ChangeUpdate, Kind: KindDatastore, Workspace: ws.Name, Name: ds.Name, Fields: fields, apply: func(g *GeoServer) error {
+			_, err := g.UpdateDatastore(ws.Name, ds.Name, update, fields...)
+			return err
+		}})
+	}
+	if !p.manifest.Prune {
+		return nil
+	}
+	for name := range live {
+		if !defined[name] {
+			name := name
+			p.add(ManifestChange{Action: ChangeDelete, Kind: KindDatastore, Workspace: ws.Name, Name: name, apply: func(g *GeoServer) error {
+				_, err := g.DeleteDatastore(ws.Name, name, true)
+				return err
+			}})
+		}
+	}
+	return nil
+}
+
+// manifestLayerStyles returns the layer styles described by the manifest layer
+func (ws ManifestWorkspace) manifestLayerStyles(l ManifestLayer) (defaultStyle *Resource, styles *LayerStyles) {
+	if l.DefaultStyle != "" {
+		defaultStyle = &Resource{Name: ws.qualifyStyle(l.DefaultStyle)}
+	}
+	if l.Styles != nil {
+		styles = &LayerStyles{Style: make([]Resource, 0, len(l.Styles))}
+		for _, s := range l.Styles {
+			styles.Style = append(styles.Style, Resource{Name: ws.qualifyStyle(s)})
+		}
+	}
+	return
+}
+
+func (p *manifestPlanner) planLayers(ws ManifestWorkspace, exists bool) error {
+	live := map[string]bool{}
+	if exists {
+		liveLayers, err := p.g.GetLayers(ws.Name)
+		if err != nil {
+			return err
+		}
+		live = liveNames(liveLayers)
+	}
+	defined := make(map[string]bool, len(ws.Layers))
+	for _, l := range ws.Layers {
+		l := l
+		defined[l.Name] = true
+		defaultStyle, styles := ws.manifestLayerStyles(l)
+		featureType := &FeatureType{
+			Name:       l.Name,
+			NativeName: l.NativeName,
+			Title:      l.Title,
+			Abstract:   l.Abstract,
+			Srs:        l.Srs,
+			Store:      &Resource{Name: qualifyName(ws.Name, l.Datastore)},
+		}
+		layer := Layer{DefaultStyle: defaultStyle, Styles: styles, Enabled: l.Enabled}
+
+		if !live[l.Name] {
+			if featureType.NativeName == "" {
+				featureType.NativeName = l.Name
+			}
+			if featureType.Srs != "" {
+				featureType.ProjectionPolicy = "FORCE_DECLARED"
+			}
+			featureType.Enabled = true
+			p.add(ManifestChange{Action: ChangeCreate, Kind: KindLayer, Workspace: ws.Name, Name: l.Name, apply: func(g *GeoServer) error {
+				if _, err := g.CreateFeatureType(ws.Name, l.Datastore, featureType); err != nil {
+					return err
+				}
+				if defaultStyle == nil && styles == nil && l.Enabled == nil {
+					return nil
+				}
+				_, err := g.UpdateLayer(ws.Name, l.Name, layer)
+				return err
+			}})
+			if l.GWC != nil {
+				p.planGWCLayer(ws.Name, l, nil)
+			}
+			continue
+		}
+
+		liveFeatureType, err := p.g.GetFeatureType(ws.Name, l.Datastore, l.Name)
+		if err != nil {
+			return fmt.Errorf("can't get layer %s:%s from datastore %s: %v", ws.Name, l.Name, l.Datastore, err)
+		}
+		var featureTypeFields []string
+		if l.Title != "" && l.Title != liveFeatureType.Title {
+			featureTypeFields = append(featureTypeFields, "title")
+		}
+		if l.Abstract != "" && l.Abstract != liveFeatureType.Abstract {
+			featureTypeFields = append(featureTypeFields, "abstract")
+		}
+		if l.Srs != "" && l.Srs != liveFeatureType.Srs {
+			featureTypeFields = append(featureTypeFields, "srs")
+		}
+
+		liveLayer, err := p.g.GetLayer(ws.Name, l.Name)
+		if err != nil {
+			return err
+		}
+		var layerFields []string
+		if defaultStyle != nil && (liveLayer.DefaultStyle == nil || liveLayer.DefaultStyle.Name != defaultStyle.Name) {
+			layerFields = append(layerFields, "defaultStyle")
+		}
+		if styles != nil && !sameLayerStyles(liveLayer.Styles, styles) {
+			layerFields = append(layerFields, "styles")
+		}
+		if l.Enabled != nil && (liveLayer.Enabled == nil || *liveLayer.Enabled != *l.Enabled) {
+			layerFields = append(layerFields, "enabled")
+		}
+
+		// the native name isn't updated
+		featureTypeUpdate := &FeatureType{Name: l.Name, Title: l.Title, Abstract: l.Abstract, Srs: l.Srs, Store: featureType.Store}
+		if len(featureTypeFields)+len(layerFields) != 0 {
+			p.add(ManifestChange{Action: ChangeUpdate, Kind: KindLayer, Workspace: ws.Name, Name: l.Name,
+				Fields: append(append([]string{}, featureTypeFields...), layerFields...), apply: func(g *GeoServer) error {
+					if len(featureTypeFields) != 0 {
+						if _, err := g.UpdateFeatureType(ws.Name, featureTypeUpdate, l.Name, nil, featureTypeFields...); err != nil {
+							return err
+						}
+					}
+					if len(layerFields) != 0 {
+						if _, err := g.UpdateLayer(ws.Name, l.Name, layer, layerFields...); err != nil {
+							return err
+						}
+					}
+					return nil
+				}})
+		}
+		if l.GWC != nil {
+			liveGwcLayer, err := p.g.GetGwcLayer(ws.Name, l.Name)
+			if err != nil && !isNotFoundError(err) {
+				return err
+			}
+			if err != nil {
+				p.planGWCLayer(ws.Name, l, nil)
+			} else {
+				p.planGWCLayer(ws.Name, l, &liveGwcLayer)
+			}
+		}
+	}
+	if !p.manifest.Prune {
+		return nil
+	}
+	names := make([]string, 0, len(live))
+	for name := range live {
+		if !defined[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name := name
+		// only the layers of the datastore feature types can be described by the manifest,
+		// the coverage and the cascaded layers are kept
+		liveLayer, err := p.g.GetLayer(ws.Name, name)
+		if err != nil {
+			return err
+		}
+		if datastoreName, _ := featureTypeFromHref(liveLayer.Resource.Href); datastoreName == "" {
+			continue
+		}
+		p.add(ManifestChange{Action: ChangeDelete, Kind: KindLayer, Workspace: ws.Name, Name: name, apply: func(g *GeoServer) error {
+			_, err := g.DeleteLayer(ws.Name, name, true)
+			return err
+		}})
+	}
+	return nil
+}
+
+// sameLayerStyles compares the styles names ignoring the order
+func sameLayerStyles(live *LayerStyles, styles *LayerStyles) bool {
+	var liveStyles []Resource
+	if live != nil {
+		liveStyles = live.Style
+	}
+	if len(liveStyles) != len(styles.Style) {
+		return false
+	}
+	for _, s := range styles.Style {
+		found := false
+		for _, ls := range liveStyles {
+			if ls.Name == s.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStringSet compares the lists ignoring the order
+func sameStringSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// planGWCLayer plans GeoWebCache layer change, live is nil if the caching layer doesn't exist yet
+func (p *manifestPlanner) planGWCLayer(workspaceName string, l ManifestLayer, live *GwcLayer) {
+	gwc := *l.GWC
+	var fields []string
+	if live != nil {
+		if gwc.Enabled != nil && *gwc.Enabled != live.Enabled {
+			fields = append(fields, "enabled")
+		}
+		if gwc.GridSets != nil {
+			gridSets := make([]string, 0, len(live.GridSubsets))
+			for _, s := range live.GridSubsets {
+				gridSets = append(gridSets, s.GridSetName)
+			}
+			if !sameStringSet(gridSets, gwc.GridSets) {
+				fields = append(fields, "gridSets")
+			}
+		}
+		if gwc.Formats != nil && !sameStringSet(live.MimeFormats, gwc.Formats) {
+			fields = append(fields, "formats")
+		}
+		if gwc.MetaTiling != nil && fmt.Sprint(live.MetaWidthHeight) != fmt.Sprint(gwc.MetaTiling) {
+			fields = append(fields, "metaTiling")
+		}
+		if gwc.ExpireCache != 0 && gwc.ExpireCache != live.ExpireCache {
+			fields = append(fields, "expireCache")
+		}
+		if len(fields) == 0 {
+			return
+		}
+	}
+	change := ManifestChange{Action: ChangeUpdate, Kind: KindGWCLayer, Workspace: workspaceName, Name: l.Name, Fields: fields, apply: func(g *GeoServer) error {
+		layer, err := g.GetGwcLayer(workspaceName, l.Name)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+		if err != nil {
+			layer = GwcLayer{Name: qualifyName(workspaceName, l.Name), Enabled: true}
+		}
+		if gwc.Enabled != nil {
+			layer.Enabled = *gwc.Enabled
+		}
+		if gwc.GridSets != nil {
+			layer.GridSubsets = make([]GwcLayerGridSubset, 0, len(gwc.GridSets))
+			for _, name := range gwc.GridSets {
+				layer.GridSubsets = append(layer.GridSubsets, GwcLayerGridSubset{GridSetName: name})
+			}
+		}
+		if gwc.Formats != nil {
+			layer.MimeFormats = gwc.Formats
+		}
+		if gwc.MetaTiling != nil {
+			layer.MetaWidthHeight = gwc.MetaTiling
+		}
+		if gwc.ExpireCache != 0 {
+			layer.ExpireCache = gwc.ExpireCache
+		}
+		return g.UpdateGwcLayer(layer)
+	}}
+	if live == nil {
+		change.Action = ChangeCreate
+	}
+	p.add(change)
+}
+
+// manifestLayerGroup builds the layergroup described by the manifest
+func (ws ManifestWorkspace) manifestLayerGroup(mlg ManifestLayerGroup) *LayerGroup {
+	lg := &LayerGroup{Name: mlg.Name, Mode: mlg.Mode, Title: mlg.Title, Abstract: mlg.Abstract, Workspace: &Resource{Name: ws.Name}}
+	if lg.Mode == "" {
+		lg.Mode = LayerGroupModeSingle
+	}
+	for i, name := range mlg.Layers {
+		item := NewLayerPublishable(qualifyName(ws.Name, name))
+		if ws.isLayerGroup(name) {
+			item = NewLayerGroupPublishable(qualifyName(ws.Name, name))
+		}
+		var style *Resource
+		if i < len(mlg.Styles) && mlg.Styles[i] != "" {
+			style = &Resource{Name: ws.qualifyStyle(mlg.Styles[i])}
+		}
+		lg.AddPublishable(item, style)
+	}
+	return lg
+}
+
+// orderedLayerGroups returns the layergroups of the workspace ordered so nested groups precede the groups containing them
+func (ws ManifestWorkspace) orderedLayerGroups() []ManifestLayerGroup {
+	byName := make(map[string]ManifestLayerGroup, len(ws.LayerGroups))
+	for _, lg := range ws.LayerGroups {
+		byName[lg.Name] = lg
+	}
+	visited := make(map[string]bool, len(ws.LayerGroups))
+	ordered := make([]ManifestLayerGroup, 0, len(ws.LayerGroups))
+	var visit func(lg ManifestLayerGroup)
+	visit = func(lg ManifestLayerGroup) {
+		if visited[lg.Name] {
+			return
+		}
+		visited[lg.Name] = true
+		for _, name := range lg.Layers {
+			if ws.isLayerGroup(name) {
+				_, groupName := splitQualifiedName(name)
+				visit(byName[groupName])
+			}
+		}
+		ordered = append(ordered, lg)
+	}
+	for _, lg := range ws.LayerGroups {
+		visit(lg)
+	}
+	return ordered
+}
+
+// sameLayerGroup compares the layergroup properties described by the manifest
+func sameLayerGroup(live *LayerGroup, lg *LayerGroup) (fields []string) {
+	if live.Mode != lg.Mode {
+		fields = append(fields, "mode")
+	}
+	if lg.Title != "" && live.Title != lg.Title {
+		fields = append(fields, "title")
+	}
+	if lg.Abstract != "" && live.Abstract != lg.Abstract {
+		fields = append(fields, "abstract")
+	}
+	livePublished, published := live.Publishables.Published, lg.Publishables.Published
+	samePublished := len(livePublished) == len(published)
+	for i := 0; samePublished && i < len(published); i++ {
+		samePublished = qualifyName(lg.Workspace.Name, livePublished[i].Name) == published[i].Name
+	}
+	if !samePublished {
+		fields = append(fields, "publishables")
+	}
+	sameStyles := len(live.Styles.Style) == len(lg.Styles.Style)
+	for i := 0; sameStyles && i < len(lg.Styles.Style); i++ {
+		liveStyle, style := live.Styles.Style[i], lg.Styles.Style[i]
+		sameStyles = (liveStyle == nil && style == nil) || (liveStyle != nil && style != nil && liveStyle.Name == style.Name)
+	}
+	if !sameStyles {
+		fields = append(fields, "styles")
+	}
+	return
+}
+
+func (p *manifestPlanner) planLayerGroups(ws ManifestWorkspace, exists bool) error {
+	live := map[string]bool{}
+	if exists {
+		liveGroups, err := p.g.GetLayerGroups(ws.Name)
+		if err != nil {
+			return err
+		}
+		live = liveNames(liveGroups)
+	}
+	defined := make(map[string]bool, len(ws.LayerGroups))
+	for _, mlg := range ws.orderedLayerGroups() {
+		defined[mlg.Name] = true
+		lg := ws.manifestLayerGroup(mlg)
+		if !live[mlg.Name] {
+			p.add(ManifestChange{Action: ChangeCreate, Kind: KindLayerGroup, Workspace: ws.Name, Name: mlg.Name, apply: func(g *GeoServer) error {
+				_, err := g.CreateLayerGroup(ws.Name, lg)
+				return err
+			}})
+			continue
+		}
+		liveGroup, err := p.g.GetLayerGroup(ws.Name, mlg.Name)
+		if err != nil {
+			return err
+		}
+		if fields := sameLayerGroup(liveGroup, lg); len(fields) != 0 {
+			name := mlg.Name
+			p.add(ManifestChange{Action: ChangeUpdate, Kind: KindLayerGroup, Workspace: ws.Name, Name: name, Fields: fields, apply: func(g *GeoServer) error {
+				_, err := g.UpdateLayerGroup(ws.Name, name, lg)
+				return err
+			}})
+		}
+	}
+	if !p.manifest.Prune {
+		return nil
+	}
+	for name := range live {
+		if !defined[name] {
+			name := name
+			p.add(ManifestChange{Action: ChangeDelete, Kind: KindLayerGroup, Workspace: ws.Name, Name: name, apply: func(g *GeoServer) error {
+				_, err := g.DeleteLayerGroup(ws.Name, name)
+				return err
+			}})
+		}
+	}
+	return nil
+}
+
+func (p *manifestPlanner) planACL() error {
+	if len(p.manifest.ACL) == 0 && !p.manifest.Prune {
+		return nil
+	}
+	liveRules, err := p.g.GetLayersAclRules()
+	if err != nil {
+		return err
+	}
+	live := make(map[string]AclRule, len(liveRules))
+	for _, r := range liveRules {
+		ruleString, _ := r.ToStrings()
+		live[ruleString] = r
+	}
+	defined := make(map[string]bool, len(p.manifest.ACL))
+	for _, mr := range p.manifest.ACL {
+		rule := mr.AclRule()
+		ruleString, roles := rule.ToStrings()
+		defined[ruleString] = true
+		liveRule, exists := live[ruleString]
+		if !exists {
+			p.add(ManifestChange{Action: ChangeCreate, Kind: KindACLRule, Name: ruleString, apply: func(g *GeoServer) error {
+				_, err := g.AddLayersAclRule(rule)
+				return err
+			}})
+			continue
+		}
+		if _, liveRoles := liveRule.ToStrings(); !sameStringSet(strings.Split(liveRoles, ","), strings.Split(roles, ",")) {
+			p.add(ManifestChange{Action: ChangeUpdate, Kind: KindACLRule, Name: ruleString, Fields: []string{"roles"}, apply: func(g *GeoServer) error {
+				_, err := g.UpdateLayersAclRule(rule)
+				return err
+			}})
+		}
+	}
+	if !p.manifest.Prune {
+		return nil
+	}
+	managed := make(map[string]bool, len(p.manifest.Workspaces))
+	for _, ws := range p.manifest.Workspaces {
+		managed[ws.Name] = true
+	}
+	for ruleString, rule := range live {
+		if !defined[ruleString] && managed[rule.Workspace] {
+			rule := rule
+			p.add(ManifestChange{Action: ChangeDelete, Kind: KindACLRule, Name: ruleString, apply: func(g *GeoServer) error {
+				_, err := g.DeleteLayersAclRule(rule)
+				return err
+			}})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package geoserver
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testManifest = `
+styles:
+  - name: line
+    body: <StyledLayerDescriptor/>
+  - name: poly
+    body: <StyledLayerDescriptor version="1.0.0"/>
+workspaces:
+  - name: parks
+    isolated: true
+    styles:
+      - name: park
+        body: <StyledLayerDescriptor/>
+    datastores:
+      - name: pg
+        connection:
+          dbtype: postgis
+          host: localhost
+          passwd: secret
+    layers:
+      - name: parks
+        datastore: pg
+        nativeName: parks_table
+        defaultStyle: park
+        styles: [line]
+        gwc:
+          gridSets: [EPSG:3857]
+          formats: [image/png]
+    layerGroups:
+      - name: all
+        layers: [parks, nested]
+        styles: ["", line]
+      - name: nested
+        layers: [parks]
+acl:
+  - workspace: parks
+    layer: "*"
+    operation: r
+    roles: [ROLE_PARKS]
+`
+
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest), ".")
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Workspaces, 1)
+	ws := manifest.Workspaces[0]
+	assert.True(t, ws.Isolated)
+	assert.Equal(t, "parks:park", ws.qualifyStyle("park"))
+	assert.Equal(t, "line", ws.qualifyStyle("line"))
+	assert.True(t, ws.isLayerGroup("parks:nested"))
+	assert.False(t, ws.isLayerGroup("parks"))
+	assert.Equal(t, []string{"nested", "all"}, []string{ws.orderedLayerGroups()[0].Name, ws.orderedLayerGroups()[1].Name})
+
+	datastore := ws.Datastores[0].GetDatastoreObj()
+	assert.True(t, datastore.Enabled)
+	assert.Equal(t, "dbtype", datastore.ConnectionParameters.Entry[0].Key)
+
+	lg := ws.manifestLayerGroup(ws.LayerGroups[0])
+	assert.Equal(t, LayerGroupModeSingle, lg.Mode)
+	assert.Len(t, lg.Publishables.Published, 2)
+	assert.Equal(t, "parks:nested", lg.Publishables.Published[1].Name)
+
+	data, err := manifest.Marshal()
+	assert.Nil(t, err)
+	reparsed, err := ParseManifest(data, ".")
+	assert.Nil(t, err)
+	assert.Equal(t, manifest, reparsed)
+
+	_, err = ParseManifest([]byte("workspaces:\n  - name: ws\n    layers:\n      - name: roads\n        datastore: missing\n"), ".")
+	assert.NotNil(t, err)
+	_, err = ParseManifest([]byte("styles:\n  - name: empty\n"), ".")
+	assert.NotNil(t, err)
+}
+
+func TestPlanManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces/parks":
+			w.WriteHeader(http.StatusNotFound)
+		case "/rest/styles":
+			w.Write([]byte(`{"styles":{"style":[{"name":"line"}]}}`))
+		case "/rest/styles/line":
+			w.Write([]byte("<StyledLayerDescriptor/>\n"))
+		case "/rest/security/acl/layers":
+			w.Write([]byte(`{"parks.*.r":"ROLE_OLD"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	manifest, err := ParseManifest([]byte(testManifest), ".")
+	assert.Nil(t, err)
+	plan, err := GetCatalog(server.URL+"/", "admin", "geoserver").Plan(manifest)
+	assert.Nil(t, err)
+	assert.False(t, plan.Empty())
+	assert.Equal(t, `+ workspace parks
++ style poly
++ style parks:park
++ datastore parks:pg
++ layer parks:parks
++ gwcLayer parks:parks
++ layerGroup parks:nested
++ layerGroup parks:all
+~ aclRule parks.*.r (roles)`, plan.String())
+}
+
+func TestApplyManifestPlan(t *testing.T) {
+	var applied []string
+	change := func(name string, err error) ManifestChange {
+		return ManifestChange{Action: ChangeCreate, Kind: KindLayer, Workspace: "ws", Name: name, apply: func(g *GeoServer) error {
+			applied = append(applied, name)
+			return err
+		}}
+	}
+	plan := &ManifestPlan{Changes: []ManifestChange{change("a", nil), change("b", errors.New("failed")), change("c", nil)}}
+	count, err := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver").Apply(plan)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"a", "b"}, applied)
+}
+
+func TestPlanPruneLayers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces/ws/layers":
+			w.Write([]byte(`{"layers":{"layer":[{"name":"ws:roads"},{"name":"ws:dem"},{"name":"ws:cascaded"}]}}`))
+		case "/rest/workspaces/ws/layers/roads":
+			w.Write([]byte(`{"layer":{"name":"roads","resource":{"href":"http://localhost/geoserver/rest/workspaces/ws/datastores/pg/featuretypes/roads.json"}}}`))
+		case "/rest/workspaces/ws/layers/dem":
+			w.Write([]byte(`{"layer":{"name":"dem","resource":{"href":"http://localhost/geoserver/rest/workspaces/ws/coveragestores/dem/coverages/dem.json"}}}`))
+		case "/rest/workspaces/ws/layers/cascaded":
+			w.Write([]byte(`{"layer":{"name":"cascaded","resource":{"href":"http://localhost/geoserver/rest/workspaces/ws/wmsstores/remote/wmslayers/cascaded.json"}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	p := &manifestPlanner{g: GetCatalog(server.URL+"/", "admin", "geoserver"), manifest: &Manifest{Prune: true},
+		upserts: map[string][]ManifestChange{}, deletes: map[string][]ManifestChange{}}
+	assert.Nil(t, p.planLayers(ManifestWorkspace{Name: "ws"}, true))
+	assert.Len(t, p.deletes[KindLayer], 1)
+	assert.Equal(t, "roads", p.deletes[KindLayer][0].Name)
+}
+
+func TestPlanDatastoreKeepsEnabled(t *testing.T) {
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/workspaces/ws/datastores":
+			w.Write([]byte(`{"dataStores":{"dataStore":[{"name":"pg"}]}}`))
+		case "GET /rest/workspaces/ws/datastores/pg":
+			w.Write([]byte(`{"dataStore":{"name":"pg","enabled":false,"connectionParameters":{"entry":[{"@key":"host","$":"db1"}]}}}`))
+		case "PUT /rest/workspaces/ws/datastores/pg":
+			body, _ := ioutil.ReadAll(r.Body)
+			updated = string(body)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	g := GetCatalog(server.URL+"/", "admin", "geoserver")
+	p := &manifestPlanner{g: g, manifest: &Manifest{},
+		upserts: map[string][]ManifestChange{}, deletes: map[string][]ManifestChange{}}
+	ws := ManifestWorkspace{Name: "ws", Datastores: []ManifestDatastore{{Name: "pg", Connection: map[string]string{"host": "db2"}}}}
+	assert.Nil(t, p.planDatastores(ws, true))
+	if assert.Len(t, p.upserts[KindDatastore], 1) {
+		change := p.upserts[KindDatastore][0]
+		assert.Equal(t, []string{"connectionParameters"}, change.Fields)
+		assert.Nil(t, change.apply(g))
+	}
+	assert.Contains(t, updated, `"db2"`)
+	assert.NotContains(t, updated, `"enabled"`)
+}
@@ -0,0 +1,290 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	ResourceTypeResource  = "resource"  //resource is a file
+	ResourceTypeDirectory = "directory" //resource is a directory
+	ResourceTypeUndefined = "undefined" //resource doesn't exist
+)
+
+// resourceTimeLayout is the layout of the time in geoserver resource metadata, e.g. 2017-09-04 15:58:08.0 UTC
+const resourceTimeLayout = "2006-01-02 15:04:05 MST"
+
+// ResourceService define geoserver data directory resources operations,
+// resourcePath is the path relative to the data directory, e.g. "styles/icons/marker.svg"
+type ResourceService interface {
+
+	// GetResourceDirectory returns the directory listing else return error
+	GetResourceDirectory(resourcePath string) (directory *ResourceDirectory, err error)
+
+	// GetResourceMetadata returns the resource metadata else return error
+	GetResourceMetadata(resourcePath string) (metadata *ResourceMetadata, err error)
+
+	// OpenResource opens the resource for reading, the caller must close the reader
+	OpenResource(resourcePath string) (reader io.ReadCloser, err error)
+
+	// ReadResource copies the resource content to the writer
+	ReadResource(resourcePath string, writer io.Writer) (written int64, err error)
+
+	// WriteResource creates or overwrites the resource with the reader content
+	WriteResource(resourcePath string, contentType string, reader io.Reader) (err error)
+
+	// MoveResource moves the resource or the directory to the targetPath
+	MoveResource(sourcePath string, targetPath string) (err error)
+
+	// CopyResource copies the resource to the targetPath
+	CopyResource(sourcePath string, targetPath string) (err error)
+
+	// DeleteResource deletes the resource or the directory with its content
+	DeleteResource(resourcePath string) (deleted bool, err error)
+}
+
+// ResourceChild is an item of the resource directory listing, Type is the mime type of the item content
+type ResourceChild struct {
+	Name string
+	Href string
+	Type string
+}
+
+// ResourceDirectory is the listing of geoserver data directory resource directory
+type ResourceDirectory struct {
+	Name         string
+	Parent       string
+	LastModified time.Time
+	Children     []ResourceChild
+}
+
+// ResourceMetadata is the metadata of geoserver data directory resource,
+// Type is one of ResourceType* constants, Size is -1 if the server doesn't report it
+type ResourceMetadata struct {
+	Name         string
+	Parent       string
+	LastModified time.Time
+	Type         string
+	Size         int64
+}
+
+// resourceLink is the link of the resource in the geoserver response
+type resourceLink struct {
+	Href string `json:"href"`
+	Type string `json:"type"`
+}
+
+// resourceParent is the parent of the resource in the geoserver response
+type resourceParent struct {
+	Path string `json:"path"`
+}
+
+// resourceChildren is the children list of the directory in the geoserver response,
+// geoserver returns "" for the empty directory and an object for the single child
+type resourceChildren []resourceChild
+
+// resourceChild is the child of the directory in the geoserver response
+type resourceChild struct {
+	Name string       `json:"name"`
+	Link resourceLink `json:"link"`
+}
+
+// UnmarshalJSON custom deserialization to handle a single child object and an empty directory
+func (c *resourceChildren) UnmarshalJSON(data []byte) error {
+	*c = resourceChildren{}
+	if isEmptyJSONList(data) {
+		return nil
+	}
+	var children struct {
+		Child json.RawMessage `json:"child"`
+	}
+	if err := json.Unmarshal(data, &children); err != nil {
+		return err
+	}
+	return unmarshalJSONList(children.Child, (*[]resourceChild)(c))
+}
+
+// parseResourceTime parses the time of geoserver resource metadata, the zero time is returned for the empty value
+func parseResourceTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(resourceTimeLayout, value)
+}
+
+// GetResourceDirectory returns the listing of the data directory resource directory,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetResourceDirectory(resourcePath string) (directory *ResourceDirectory, err error) {
+	targetURL := g.ParseURL("rest", "resource", resourcePath)
+	var response struct {
+		Directory *struct {
+			Name         string           `json:"name"`
+			Parent       resourceParent   `json:"parent"`
+			LastModified string           `json:"lastModified"`
+			Children     resourceChildren `json:"children"`
+		} `json:"ResourceDirectory"`
+	}
+	if err = g.requestResource(targetURL, &response); err != nil {
+		return nil, err
+	}
+	if response.Directory == nil {
+		return nil, fmt.Errorf("resource %s isn't a directory", resourcePath)
+	}
+	directory = &ResourceDirectory{
+		Name:     response.Directory.Name,
+		Parent:   response.Directory.Parent.Path,
+		Children: make([]ResourceChild, 0, len(response.Directory.Children)),
+	}
+	if directory.LastModified, err = parseResourceTime(response.Directory.LastModified); err != nil {
+		return nil, err
+	}
+	for _, c := range response.Directory.Children {
+		directory.Children = append(directory.Children, ResourceChild{Name: c.Name, Href: c.Link.Href, Type: c.Link.Type})
+	}
+	return
+}
+
+// GetResourceMetadata returns the metadata of the data directory resource,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetResourceMetadata(resourcePath string) (metadata *ResourceMetadata, err error) {
+	targetURL := g.ParseURL("rest", "resource", resourcePath)
+	response, err := g.doResourceRequest(http.MethodGet, targetURL, jsonType, nil, "", map[string]string{"operation": "metadata"})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var responseData struct {
+		Metadata struct {
+			Name         string         `json:"name"`
+			Parent       resourceParent `json:"parent"`
+			LastModified string         `json:"lastModified"`
+			Type         string         `json:"type"`
+		} `json:"ResourceMetadata"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return nil, fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
+	}
+	metadata = &ResourceMetadata{
+		Name:   responseData.Metadata.Name,
+		Parent: responseData.Metadata.Parent.Path,
+		Type:   responseData.Metadata.Type,
+		Size:   -1,
+	}
+	if metadata.LastModified, err = parseResourceTime(responseData.Metadata.LastModified); err != nil {
+		return nil, err
+	}
+	if metadata.Type == ResourceTypeResource {
+		head, err := g.doResourceRequest(http.MethodHead, targetURL, "", nil, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		head.Body.Close()
+		metadata.Size = head.ContentLength
+	}
+	return
+}
+
+// OpenResource opens the data directory resource for reading, the content is streamed from the server,
+// the caller must close the reader
+func (g *GeoServer) OpenResource(resourcePath string) (reader io.ReadCloser, err error) {
+	targetURL := g.ParseURL("rest", "resource", resourcePath)
+	response, err := g.doResourceRequest(http.MethodGet, targetURL, "", nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// ReadResource copies the content of the data directory resource to the writer without full buffering,
+// written is the number of bytes copied
+func (g *GeoServer) ReadResource(resourcePath string, writer io.Writer) (written int64, err error) {
+	reader, err := g.OpenResource(resourcePath)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	return io.Copy(writer, reader)
+}
+
+// WriteResource creates or overwrites the data directory resource with the reader content,
+// the content is streamed to the server, if contentType is empty application/octet-stream is used
+func (g *GeoServer) WriteResource(resourcePath string, contentType string, reader io.Reader) (err error) {
+	targetURL := g.ParseURL("rest", "resource", resourcePath)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	response, err := g.doResourceRequest(http.MethodPut, targetURL, "", reader, contentType, nil)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+// MoveResource moves the data directory resource or directory to the targetPath
+func (g *GeoServer) MoveResource(sourcePath string, targetPath string) (err error) {
+	return g.transferResource("move", sourcePath, targetPath)
+}
+
+// CopyResource copies the data directory resource to the targetPath
+func (g *GeoServer) CopyResource(sourcePath string, targetPath string) (err error) {
+	return g.transferResource("copy", sourcePath, targetPath)
+}
+
+// DeleteResource deletes the data directory resource or the directory with its content,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteResource(resourcePath string) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "resource", resourcePath)
+	return g.deleteEntity(targetURL)
+}
+
+// transferResource performs move or copy operation, geoserver expects the source path in the request body
+func (g *GeoServer) transferResource(operation string, sourcePath string, targetPath string) (err error) {
+	targetURL := g.ParseURL("rest", "resource", targetPath)
+	source := "/" + strings.TrimPrefix(sourcePath, "/")
+	response, err := g.doResourceRequest(http.MethodPut, targetURL, "", strings.NewReader(source), "text/plain", map[string]string{"operation": operation})
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+// doResourceRequest performs the request without buffering the request and the response bodies,
+// the response body must be closed by the caller, an error is returned for non 2xx responses
+func (g *GeoServer) doResourceRequest(method string, targetURL string, accept string, data io.Reader, contentType string, query map[string]string) (response *http.Response, err error) {
+	request := g.GetGeoserverRequest(targetURL, method, accept, data, contentType)
+	if request == nil {
+		return nil, fmt.Errorf("can't create %s request to %s", method, targetURL)
+	}
+	if len(query) != 0 {
+		q := request.URL.Query()
+		for k, v := range query {
+			q.Add(k, v)
+		}
+		request.URL.RawQuery = q.Encode()
+	}
+	if statusCode, ok := g.recordMutation(request); ok {
+		return dryRunResponse(request, statusCode), nil
+	}
+	if g.cache != nil && method != getMethod && method != http.MethodHead {
+		g.cache.Invalidate(request.URL.Path)
+		defer g.cache.Invalidate(request.URL.Path)
+	}
+	response, err = g.HttpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if !LogConsoleQuiet {
+		g.logger.Infof("%s:%s  Status=%s", method, request.URL, response.Status)
+	}
+	if response.StatusCode < statusOk || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		g.logger.Error(string(body))
+		return nil, g.GetError(response.StatusCode, body)
+	}
+	return response, nil
+}
@@ -0,0 +1,111 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResources(t *testing.T) {
+	test_before(t)
+	defer func() {
+		_, _ = gsCatalog.DeleteResource("gsclient_test")
+	}()
+
+	content := []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")
+	err := gsCatalog.WriteResource("gsclient_test/icons/marker.svg", "image/svg+xml", bytes.NewReader(content))
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	written, err := gsCatalog.ReadResource("gsclient_test/icons/marker.svg", &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(content)), written)
+	assert.Equal(t, content, buffer.Bytes())
+
+	metadata, err := gsCatalog.GetResourceMetadata("gsclient_test/icons/marker.svg")
+	assert.Nil(t, err)
+	assert.Equal(t, ResourceTypeResource, metadata.Type)
+	assert.False(t, metadata.LastModified.IsZero())
+
+	err = gsCatalog.CopyResource("gsclient_test/icons/marker.svg", "gsclient_test/icons/copy.svg")
+	assert.Nil(t, err)
+	err = gsCatalog.MoveResource("gsclient_test/icons/copy.svg", "gsclient_test/moved.svg")
+	assert.Nil(t, err)
+
+	directory, err := gsCatalog.GetResourceDirectory("gsclient_test")
+	assert.Nil(t, err)
+	assert.Len(t, directory.Children, 2)
+	metadata, err = gsCatalog.GetResourceMetadata("gsclient_test/icons")
+	assert.Nil(t, err)
+	assert.Equal(t, ResourceTypeDirectory, metadata.Type)
+
+	deleted, err := gsCatalog.DeleteResource("gsclient_test/moved.svg")
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	_, err = gsCatalog.OpenResource("gsclient_test/moved.svg")
+	assert.NotNil(t, err)
+}
+
+func TestResourceDirectoryListing(t *testing.T) {
+	responses := map[string]string{
+		"/rest/resource/empty":  `{"ResourceDirectory":{"name":"empty","parent":{"path":"/"},"lastModified":"2017-09-04 15:58:08.0 UTC","children":""}}`,
+		"/rest/resource/single": `{"ResourceDirectory":{"name":"single","parent":{"path":"/"},"lastModified":"2017-09-04 15:58:08.0 UTC","children":{"child":{"name":"a.svg","link":{"href":"http://localhost/a.svg","type":"image/svg+xml"}}}}}`,
+		"/rest/resource/many":   `{"ResourceDirectory":{"name":"many","parent":{"path":"/"},"children":{"child":[{"name":"a"},{"name":"b"}]}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			if r.URL.Query().Get("operation") == "move" && string(body) != "/single/a.svg" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case responses[r.URL.Path] != "":
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		case r.URL.Path == "/rest/resource/single/a.svg":
+			_, _ = w.Write([]byte("<svg/>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	directory, err := gsCatalog.GetResourceDirectory("empty")
+	assert.Nil(t, err)
+	assert.Empty(t, directory.Children)
+	assert.Equal(t, 2017, directory.LastModified.Year())
+	directory, err = gsCatalog.GetResourceDirectory("single")
+	assert.Nil(t, err)
+	assert.Equal(t, []ResourceChild{{Name: "a.svg", Href: "http://localhost/a.svg", Type: "image/svg+xml"}}, directory.Children)
+	directory, err = gsCatalog.GetResourceDirectory("many")
+	assert.Nil(t, err)
+	assert.Len(t, directory.Children, 2)
+	_, err = gsCatalog.GetResourceDirectory("dummy")
+	assert.NotNil(t, err)
+	var children resourceChildren
+	assert.NotNil(t, json.Unmarshal([]byte(`{"child":"a"}`), &children))
+	assert.NotNil(t, json.Unmarshal([]byte(`42`), &children))
+
+	var buffer strings.Builder
+	_, err = gsCatalog.ReadResource("single/a.svg", &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "<svg/>", buffer.String())
+	assert.Nil(t, gsCatalog.MoveResource("single/a.svg", "b.svg"))
+	assert.NotNil(t, gsCatalog.MoveResource("dummy.svg", "b.svg"))
+}
+
+func TestGeoserverImplementResourceService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	ResourceServiceType := reflect.TypeOf((*ResourceService)(nil)).Elem()
+	check := gsCatalog.Implements(ResourceServiceType)
+	assert.True(t, check)
+}
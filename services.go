@@ -0,0 +1,262 @@
+package geoserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	ServiceWMS  = "wms"
+	ServiceWFS  = "wfs"
+	ServiceWCS  = "wcs"
+	ServiceWMTS = "wmts"
+)
+
+const (
+	WFSServiceLevelBasic         = "BASIC"         //WFS supports read only operations
+	WFSServiceLevelTransactional = "TRANSACTIONAL" //WFS supports transactions
+	WFSServiceLevelComplete      = "COMPLETE"      //WFS supports transactions and locking
+)
+
+const (
+	WatermarkTopLeft      = "TOP_LEFT"
+	WatermarkTopCenter    = "TOP_CENTER"
+	WatermarkTopRight     = "TOP_RIGHT"
+	WatermarkMidLeft      = "MID_LEFT"
+	WatermarkMidCenter    = "MID_CENTER"
+	WatermarkMidRight     = "MID_RIGHT"
+	WatermarkBottomLeft   = "BOT_LEFT"
+	WatermarkBottomCenter = "BOT_CENTER"
+	WatermarkBottomRight  = "BOT_RIGHT"
+)
+
+// OWSServiceSettingsService define geoserver OWS services (WMS, WFS, WCS, WMTS) configuration operations,
+// workspaceName "" means the global service configuration
+type OWSServiceSettingsService interface {
+	GetWMSSettings(workspaceName string) (settings *WMSSettings, err error)
+	UpdateWMSSettings(workspaceName string, settings WMSSettings, fields ...string) (modified bool, err error)
+	GetWFSSettings(workspaceName string) (settings *WFSSettings, err error)
+	UpdateWFSSettings(workspaceName string, settings WFSSettings, fields ...string) (modified bool, err error)
+	GetWCSSettings(workspaceName string) (settings *WCSSettings, err error)
+	UpdateWCSSettings(workspaceName string, settings WCSSettings, fields ...string) (modified bool, err error)
+	GetWMTSSettings(workspaceName string) (settings *WMTSSettings, err error)
+	UpdateWMTSSettings(workspaceName string, settings WMTSSettings, fields ...string) (modified bool, err error)
+	EnableWorkspaceService(service string, workspaceName string, enabled bool) (modified bool, err error)
+	DeleteWorkspaceServiceSettings(service string, workspaceName string) (deleted bool, err error)
+}
+
+// StringList is geoserver list of strings like {"string": [...]}
+type StringList struct {
+	String []string `json:"string,omitempty"`
+}
+
+// UnmarshalJSON decodes the list, geoserver writes a single item list as the bare string and the empty list as ""
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	l.String = nil
+	if isEmptyJSONList(data) {
+		return nil
+	}
+	var list struct {
+		String json.RawMessage `json:"string"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	value := bytes.TrimSpace(list.String)
+	if len(value) > 0 && value[0] == '"' && !isEmptyJSONList(value) {
+		var item string
+		if err := json.Unmarshal(value, &item); err != nil {
+			return err
+		}
+		l.String = []string{item}
+		return nil
+	}
+	return unmarshalJSONList(value, &l.String)
+}
+
+// Watermark is WMS watermark settings, Position is one of Watermark* constants,
+// Transparency is from 0 (opaque) to 100 (fully transparent)
+type Watermark struct {
+	Enabled      *bool  `json:"enabled,omitempty"`
+	Position     string `json:"position,omitempty"`
+	Transparency int    `json:"transparency,omitempty"`
+	URL          string `json:"URL,omitempty"`
+}
+
+// ServiceSettings is the configuration common to all geoserver OWS services,
+// Workspace is set for the workspace local service configuration only
+type ServiceSettings struct {
+	ID                string    `json:"id,omitempty"`
+	Workspace         *Resource `json:"workspace,omitempty"`
+	Enabled           *bool     `json:"enabled,omitempty"`
+	Name              string    `json:"name,omitempty"`
+	Title             string    `json:"title,omitempty"`
+	Maintainer        string    `json:"maintainer,omitempty"`
+	Abstract          string    `json:"abstrct,omitempty"`
+	AccessConstraints string    `json:"accessConstraints,omitempty"`
+	Fees              string    `json:"fees,omitempty"`
+	Keywords          *Keywords `json:"keywords,omitempty"`
+	CiteCompliant     *bool     `json:"citeCompliant,omitempty"`
+	OnlineResource    string    `json:"onlineResource,omitempty"`
+	SchemaBaseURL     string    `json:"schemaBaseURL,omitempty"`
+	Verbose           *bool     `json:"verbose,omitempty"`
+	Metadata          *Metadata `json:"metadata,omitempty"`
+}
+
+// WMSSettings is geoserver WMS service configuration,
+// MaxRequestMemory is in kilobytes, MaxRenderingTime is in seconds, 0 means no limit
+type WMSSettings struct {
+	ServiceSettings
+	SRS                         *StringList `json:"srs,omitempty"`
+	BBOXForEachCRS              *bool       `json:"bboxForEachCRS,omitempty"`
+	Watermark                   *Watermark  `json:"watermark,omitempty"`
+	Interpolation               string      `json:"interpolation,omitempty"`
+	MaxBuffer                   int         `json:"maxBuffer,omitempty"`
+	MaxRequestMemory            int         `json:"maxRequestMemory,omitempty"`
+	MaxRenderingTime            int         `json:"maxRenderingTime,omitempty"`
+	MaxRenderingErrors          int         `json:"maxRenderingErrors,omitempty"`
+	MaxRequestedDimensionValues int         `json:"maxRequestedDimensionValues,omitempty"`
+	DynamicStylingDisabled      *bool       `json:"dynamicStylingDisabled,omitempty"`
+}
+
+// WFSSettings is geoserver WFS service configuration, ServiceLevel is one of WFSServiceLevel* constants
+type WFSSettings struct {
+	ServiceSettings
+	SRS                           *StringList `json:"srs,omitempty"`
+	ServiceLevel                  string      `json:"serviceLevel,omitempty"`
+	MaxFeatures                   int         `json:"maxFeatures,omitempty"`
+	FeatureBounding               *bool       `json:"featureBounding,omitempty"`
+	CanonicalSchemaLocation       *bool       `json:"canonicalSchemaLocation,omitempty"`
+	EncodeFeatureMember           *bool       `json:"encodeFeatureMember,omitempty"`
+	HitsIgnoreMaxFeatures         *bool       `json:"hitsIgnoreMaxFeatures,omitempty"`
+	MaxNumberOfFeaturesForPreview int         `json:"maxNumberOfFeaturesForPreview,omitempty"`
+}
+
+// WCSSettings is geoserver WCS service configuration, MaxInputMemory and MaxOutputMemory are in kilobytes, 0 means no limit
+type WCSSettings struct {
+	ServiceSettings
+	SRS                *StringList `json:"srs,omitempty"`
+	GMLPrefixing       *bool       `json:"gmlPrefixing,omitempty"`
+	LatLon             *bool       `json:"latLon,omitempty"`
+	MaxInputMemory     int         `json:"maxInputMemory,omitempty"`
+	MaxOutputMemory    int         `json:"maxOutputMemory,omitempty"`
+	SubsamplingEnabled *bool       `json:"subsamplingEnabled,omitempty"`
+	OverviewPolicy     string      `json:"overviewPolicy,omitempty"`
+}
+
+// WMTSSettings is geoserver WMTS service configuration
+type WMTSSettings struct {
+	ServiceSettings
+}
+
+// GetWMSSettings returns WMS service configuration of the workspace or the global one if workspaceName is "",
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMSSettings(workspaceName string) (settings *WMSSettings, err error) {
+	var response struct {
+		WMS *WMSSettings `json:"wms"`
+	}
+	if err = g.requestResource(g.serviceSettingsURL(ServiceWMS, workspaceName), &response); err != nil {
+		return nil, err
+	}
+	return response.WMS, nil
+}
+
+// UpdateWMSSettings partial update WMS service configuration of the workspace or the global one if workspaceName is "",
+// fields is an optional field mask, a list of json field names to send even if they are zero or nil (e.g. "maxRenderingTime"),
+// without fields only the fields set in the settings are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMSSettings(workspaceName string, settings WMSSettings, fields ...string) (modified bool, err error) {
+	return g.updateServiceSettings(ServiceWMS, workspaceName, settings, fields)
+}
+
+// GetWFSSettings returns WFS service configuration of the workspace or the global one if workspaceName is "",
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWFSSettings(workspaceName string) (settings *WFSSettings, err error) {
+	var response struct {
+		WFS *WFSSettings `json:"wfs"`
+	}
+	if err = g.requestResource(g.serviceSettingsURL(ServiceWFS, workspaceName), &response); err != nil {
+		return nil, err
+	}
+	return response.WFS, nil
+}
+
+// UpdateWFSSettings partial update WFS service configuration of the workspace or the global one if workspaceName is "",
+// fields is an optional field mask, a list of json field names to send even if they are zero or nil (e.g. "maxFeatures"),
+// without fields only the fields set in the settings are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWFSSettings(workspaceName string, settings WFSSettings, fields ...string) (modified bool, err error) {
+	return g.updateServiceSettings(ServiceWFS, workspaceName, settings, fields)
+}
+
+// GetWCSSettings returns WCS service configuration of the workspace or the global one if workspaceName is "",
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWCSSettings(workspaceName string) (settings *WCSSettings, err error) {
+	var response struct {
+		WCS *WCSSettings `json:"wcs"`
+	}
+	if err = g.requestResource(g.serviceSettingsURL(ServiceWCS, workspaceName), &response); err != nil {
+		return nil, err
+	}
+	return response.WCS, nil
+}
+
+// UpdateWCSSettings partial update WCS service configuration of the workspace or the global one if workspaceName is "",
+// fields is an optional field mask, a list of json field names to send even if they are zero or nil (e.g. "maxInputMemory"),
+// without fields only the fields set in the settings are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWCSSettings(workspaceName string, settings WCSSettings, fields ...string) (modified bool, err error) {
+	return g.updateServiceSettings(ServiceWCS, workspaceName, settings, fields)
+}
+
+// GetWMTSSettings returns WMTS service configuration of the workspace or the global one if workspaceName is "",
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMTSSettings(workspaceName string) (settings *WMTSSettings, err error) {
+	var response struct {
+		WMTS *WMTSSettings `json:"wmts"`
+	}
+	if err = g.requestResource(g.serviceSettingsURL(ServiceWMTS, workspaceName), &response); err != nil {
+		return nil, err
+	}
+	return response.WMTS, nil
+}
+
+// UpdateWMTSSettings partial update WMTS service configuration of the workspace or the global one if workspaceName is "",
+// fields is an optional field mask, a list of json field names to send even if they are zero or nil (e.g. "title"),
+// without fields only the fields set in the settings are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMTSSettings(workspaceName string, settings WMTSSettings, fields ...string) (modified bool, err error) {
+	return g.updateServiceSettings(ServiceWMTS, workspaceName, settings, fields)
+}
+
+// EnableWorkspaceService enables or disables the service (one of Service* constants) on the workspace,
+// the workspace local service configuration is created if it doesn't exist yet
+func (g *GeoServer) EnableWorkspaceService(service string, workspaceName string, enabled bool) (modified bool, err error) {
+	if workspaceName == "" {
+		return false, fmt.Errorf("workspace name is expected to enable or disable the %s service", service)
+	}
+	return g.updateServiceSettings(service, workspaceName, ServiceSettings{Enabled: BoolPtr(enabled)}, nil)
+}
+
+// DeleteWorkspaceServiceSettings deletes the workspace local configuration of the service (one of Service* constants),
+// the workspace falls back to the global service configuration
+func (g *GeoServer) DeleteWorkspaceServiceSettings(service string, workspaceName string) (deleted bool, err error) {
+	if workspaceName == "" {
+		return false, fmt.Errorf("global %s service configuration can't be deleted", service)
+	}
+	return g.deleteEntity(g.serviceSettingsURL(service, workspaceName))
+}
+
+// serviceSettingsURL returns the url of the service configuration of the workspace or the global one if workspaceName is ""
+func (g *GeoServer) serviceSettingsURL(service string, workspaceName string) string {
+	if workspaceName == "" {
+		return g.ParseURL("rest", "services", service, "settings")
+	}
+	return g.ParseURL("rest", "services", service, "workspaces", workspaceName, "settings")
+}
+
+// updateServiceSettings performs PUT request of the service configuration, geoserver creates the workspace local one if it doesn't exist
+func (g *GeoServer) updateServiceSettings(service string, workspaceName string, settings interface{}, fields []string) (modified bool, err error) {
+	patch, err := patchEntity(settings, fields)
+	if err != nil {
+		return false, err
+	}
+	return g.updateSettingsEntity(g.serviceSettingsURL(service, workspaceName), map[string]interface{}{service: patch})
+}
@@ -0,0 +1,128 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWMSSettings(t *testing.T) {
+	test_before(t)
+	settings, err := gsCatalog.GetWMSSettings("")
+	assert.Nil(t, err)
+	assert.NotNil(t, settings)
+	original := settings.MaxRenderingTime
+	defer func() {
+		_, _ = gsCatalog.UpdateWMSSettings("", WMSSettings{MaxRenderingTime: original}, "maxRenderingTime")
+	}()
+
+	modified, err := gsCatalog.UpdateWMSSettings("", WMSSettings{
+		MaxRenderingTime:   60,
+		MaxRenderingErrors: 10,
+		Watermark:          &Watermark{Enabled: BoolPtr(false), Position: WatermarkBottomRight},
+	})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	settings, err = gsCatalog.GetWMSSettings("")
+	assert.Nil(t, err)
+	assert.Equal(t, 60, settings.MaxRenderingTime)
+	assert.Equal(t, 10, settings.MaxRenderingErrors)
+	assert.Equal(t, WatermarkBottomRight, settings.Watermark.Position)
+}
+
+func TestWFSSettings(t *testing.T) {
+	test_before(t)
+	settings, err := gsCatalog.GetWFSSettings("")
+	assert.Nil(t, err)
+	assert.NotNil(t, settings)
+	defer func() {
+		_, _ = gsCatalog.UpdateWFSSettings("", WFSSettings{MaxFeatures: settings.MaxFeatures, ServiceLevel: settings.ServiceLevel})
+	}()
+
+	modified, err := gsCatalog.UpdateWFSSettings("", WFSSettings{MaxFeatures: 5000, ServiceLevel: WFSServiceLevelBasic})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	updated, err := gsCatalog.GetWFSSettings("")
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, updated.MaxFeatures)
+	assert.Equal(t, WFSServiceLevelBasic, updated.ServiceLevel)
+}
+
+func TestWCSSettings(t *testing.T) {
+	test_before(t)
+	settings, err := gsCatalog.GetWCSSettings("")
+	assert.Nil(t, err)
+	assert.NotNil(t, settings)
+	wmtsSettings, err := gsCatalog.GetWMTSSettings("")
+	assert.Nil(t, err)
+	assert.NotNil(t, wmtsSettings)
+}
+
+func TestWorkspaceServiceSettings(t *testing.T) {
+	test_before(t)
+	_, err := gsCatalog.CreateWorkspace(testWorkspace)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		assert.Fail(t, "can't create workspace as a precondition for workspace service settings test")
+	}
+	defer func() {
+		_, _ = gsCatalog.DeleteWorkspace(testWorkspace, true)
+	}()
+
+	modified, err := gsCatalog.EnableWorkspaceService(ServiceWFS, testWorkspace, false)
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	settings, err := gsCatalog.GetWFSSettings(testWorkspace)
+	assert.Nil(t, err)
+	assert.False(t, *settings.Enabled)
+	assert.Equal(t, testWorkspace, settings.Workspace.Name)
+
+	modified, err = gsCatalog.UpdateWFSSettings(testWorkspace, WFSSettings{MaxFeatures: 100, SRS: &StringList{String: []string{"4326", "3857"}}})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	settings, err = gsCatalog.GetWFSSettings(testWorkspace)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, settings.MaxFeatures)
+	assert.False(t, *settings.Enabled)
+
+	deleted, err := gsCatalog.DeleteWorkspaceServiceSettings(ServiceWFS, testWorkspace)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	_, err = gsCatalog.EnableWorkspaceService(ServiceWFS, "", true)
+	assert.NotNil(t, err)
+	_, err = gsCatalog.DeleteWorkspaceServiceSettings(ServiceWFS, "")
+	assert.NotNil(t, err)
+}
+
+func TestPatchEmbeddedEntity(t *testing.T) {
+	patch, err := patchEntity(WMSSettings{ServiceSettings: ServiceSettings{Title: "maps"}, MaxRenderingTime: 0}, []string{"title", "maxRenderingTime"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "maps", "maxRenderingTime": 0}, patch)
+	patch, err = patchEntity(WMSSettings{ServiceSettings: ServiceSettings{Enabled: BoolPtr(false)}}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"enabled": false}, patch)
+}
+
+func TestStringListUnmarshalJSON(t *testing.T) {
+	for data, expected := range map[string][]string{
+		`{"srs":{"string":["4326","3857"]}}`: {"4326", "3857"},
+		`{"srs":{"string":"EPSG:4326"}}`:     {"EPSG:4326"},
+		`{"srs":{"string":""}}`:              nil,
+		`{"srs":""}`:                         nil,
+	} {
+		var settings WFSSettings
+		assert.Nil(t, json.Unmarshal([]byte(data), &settings), data)
+		assert.Equal(t, expected, settings.SRS.String, data)
+	}
+	var list StringList
+	assert.NotNil(t, json.Unmarshal([]byte(`{"string":42}`), &list))
+}
+
+func TestGeoserverImplementOWSServiceSettingsService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	OWSServiceSettingsServiceType := reflect.TypeOf((*OWSServiceSettingsService)(nil)).Elem()
+	check := gsCatalog.Implements(OWSServiceSettingsServiceType)
+	assert.True(t, check)
+}
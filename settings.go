@@ -0,0 +1,227 @@
+package geoserver
+
+// SettingsService define geoserver global and workspace settings operations
+type SettingsService interface {
+
+	// GetGlobalSettings returns geoserver global settings else return error
+	GetGlobalSettings() (settings *GlobalSettings, err error)
+
+	// UpdateGlobalSettings partial update geoserver global settings else return error, fields is an optional field mask
+	UpdateGlobalSettings(settings GlobalSettings, fields ...string) (modified bool, err error)
+
+	// GetContactInfo returns geoserver global contact information else return error
+	GetContactInfo() (contact *ContactInfo, err error)
+
+	// UpdateContactInfo partial update geoserver global contact information else return error, fields is an optional field mask
+	UpdateContactInfo(contact ContactInfo, fields ...string) (modified bool, err error)
+
+	// GetWorkspaceSettings returns the workspace local settings else return error
+	GetWorkspaceSettings(workspaceName string) (settings *Settings, err error)
+
+	// CreateWorkspaceSettings creates the workspace local settings else return error
+	CreateWorkspaceSettings(workspaceName string, settings Settings) (created bool, err error)
+
+	// UpdateWorkspaceSettings partial update the workspace local settings else return error, fields is an optional field mask
+	UpdateWorkspaceSettings(workspaceName string, settings Settings, fields ...string) (modified bool, err error)
+
+	// DeleteWorkspaceSettings deletes the workspace local settings, the workspace falls back to the global settings
+	DeleteWorkspaceSettings(workspaceName string) (deleted bool, err error)
+}
+
+// ContactInfo geoserver contact information published in the services capabilities
+type ContactInfo struct {
+	ID                           string `json:"id,omitempty"`
+	ContactPerson                string `json:"contactPerson,omitempty"`
+	ContactOrganization          string `json:"contactOrganization,omitempty"`
+	ContactPosition              string `json:"contactPosition,omitempty"`
+	ContactVoice                 string `json:"contactVoice,omitempty"`
+	ContactFacsimile             string `json:"contactFacsimile,omitempty"`
+	ContactEmail                 string `json:"contactEmail,omitempty"`
+	AddressType                  string `json:"addressType,omitempty"`
+	Address                      string `json:"address,omitempty"`
+	AddressDeliveryPoint         string `json:"addressDeliveryPoint,omitempty"`
+	AddressCity                  string `json:"addressCity,omitempty"`
+	AddressState                 string `json:"addressState,omitempty"`
+	AddressPostalCode            string `json:"addressPostalCode,omitempty"`
+	AddressCountry               string `json:"addressCountry,omitempty"`
+	AddressElectronicMailAddress string `json:"addressElectronicMailAddress,omitempty"`
+	OnlineResource               string `json:"onlineResource,omitempty"`
+	Welcome                      string `json:"welcome,omitempty"`
+}
+
+// Settings geoserver settings, used both as the global settings and the workspace local settings,
+// Workspace is set for the workspace local settings only
+type Settings struct {
+	ID                           string       `json:"id,omitempty"`
+	Workspace                    *Resource    `json:"workspace,omitempty"`
+	Title                        string       `json:"title,omitempty"`
+	Contact                      *ContactInfo `json:"contact,omitempty"`
+	Charset                      string       `json:"charset,omitempty"`
+	NumDecimals                  int          `json:"numDecimals,omitempty"`
+	OnlineResource               string       `json:"onlineResource,omitempty"`
+	ProxyBaseURL                 string       `json:"proxyBaseUrl,omitempty"`
+	SchemaBaseURL                string       `json:"schemaBaseUrl,omitempty"`
+	Verbose                      *bool        `json:"verbose,omitempty"`
+	VerboseExceptions            *bool        `json:"verboseExceptions,omitempty"`
+	LocalWorkspaceIncludesPrefix *bool        `json:"localWorkspaceIncludesPrefix,omitempty"`
+	UseHeadersProxyURL           *bool        `json:"useHeadersProxyURL,omitempty"`
+	Metadata                     *Metadata    `json:"metadata,omitempty"`
+}
+
+// JAISettings geoserver Java Advanced Imaging settings, MemoryCapacity and MemoryThreshold are fractions of the JVM heap
+type JAISettings struct {
+	AllowInterpolation *bool   `json:"allowInterpolation,omitempty"`
+	Recycling          *bool   `json:"recycling,omitempty"`
+	TilePriority       int     `json:"tilePriority,omitempty"`
+	TileThreads        int     `json:"tileThreads,omitempty"`
+	MemoryCapacity     float64 `json:"memoryCapacity,omitempty"`
+	MemoryThreshold    float64 `json:"memoryThreshold,omitempty"`
+	ImageIOCache       *bool   `json:"imageIOCache,omitempty"`
+	PngAcceleration    *bool   `json:"pngAcceleration,omitempty"`
+	JpegAcceleration   *bool   `json:"jpegAcceleration,omitempty"`
+	AllowNativeMosaic  *bool   `json:"allowNativeMosaic,omitempty"`
+	AllowNativeWarp    *bool   `json:"allowNativeWarp,omitempty"`
+	PngEncoderType     string  `json:"pngEncoderType,omitempty"`
+}
+
+// CoverageAccessSettings geoserver coverage access settings, KeepAliveTime is in milliseconds,
+// QueueType is one of UNBOUNDED, DIRECT
+type CoverageAccessSettings struct {
+	MaxPoolSize           int    `json:"maxPoolSize,omitempty"`
+	CorePoolSize          int    `json:"corePoolSize,omitempty"`
+	KeepAliveTime         int    `json:"keepAliveTime,omitempty"`
+	QueueType             string `json:"queueType,omitempty"`
+	ImageIOCacheThreshold int    `json:"imageIOCacheThreshold,omitempty"`
+}
+
+// GlobalSettings geoserver global settings
+type GlobalSettings struct {
+	Settings                       *Settings               `json:"settings,omitempty"`
+	JAI                            *JAISettings            `json:"jai,omitempty"`
+	CoverageAccess                 *CoverageAccessSettings `json:"coverageAccess,omitempty"`
+	UpdateSequence                 int                     `json:"updateSequence,omitempty"`
+	FeatureTypeCacheSize           int                     `json:"featureTypeCacheSize,omitempty"`
+	GlobalServices                 *bool                   `json:"globalServices,omitempty"`
+	XMLPostRequestLogBufferSize    int                     `json:"xmlPostRequestLogBufferSize,omitempty"`
+	XMLExternalEntitiesEnabled     *bool                   `json:"xmlExternalEntitiesEnabled,omitempty"`
+	WebUIMode                      string                  `json:"webUIMode,omitempty"`
+	AllowStoredQueriesPerWorkspace *bool                   `json:"allowStoredQueriesPerWorkspace,omitempty"`
+}
+
+// GlobalSettingsRequestBody geoserver global settings to send to api
+type GlobalSettingsRequestBody struct {
+	Global *GlobalSettings `json:"global,omitempty"`
+}
+
+// SettingsRequestBody geoserver workspace settings to send to api
+type SettingsRequestBody struct {
+	Settings *Settings `json:"settings,omitempty"`
+}
+
+// ContactInfoRequestBody geoserver contact information to send to api
+type ContactInfoRequestBody struct {
+	Contact *ContactInfo `json:"contact,omitempty"`
+}
+
+// GetGlobalSettings returns geoserver global settings,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetGlobalSettings() (settings *GlobalSettings, err error) {
+	targetURL := g.ParseURL("rest", "settings")
+	var settingsResponse GlobalSettingsRequestBody
+	if err = g.requestResource(targetURL, &settingsResponse); err != nil {
+		return nil, err
+	}
+	return settingsResponse.Global, nil
+}
+
+// UpdateGlobalSettings partial update geoserver global settings else return error,
+// fields is an optional field mask, a list of global settings json field names to send even if they are zero or nil (e.g. "featureTypeCacheSize"),
+// geoserver replaces the nested objects (settings, jai, coverageAccess) as a whole, so the fields set in the settings
+// are merged into the current global settings and the others are kept
+func (g *GeoServer) UpdateGlobalSettings(settings GlobalSettings, fields ...string) (modified bool, err error) {
+	patch, err := patchEntity(settings, fields)
+	if err != nil {
+		return false, err
+	}
+	current, err := g.GetGlobalSettings()
+	if err != nil {
+		return false, err
+	}
+	merged, err := mergeEntity(current, patch)
+	if err != nil {
+		return false, err
+	}
+	targetURL := g.ParseURL("rest", "settings")
+	return g.updateSettingsEntity(targetURL, map[string]interface{}{"global": merged})
+}
+
+// GetContactInfo returns geoserver global contact information,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetContactInfo() (contact *ContactInfo, err error) {
+	targetURL := g.ParseURL("rest", "settings", "contact")
+	var contactResponse ContactInfoRequestBody
+	if err = g.requestResource(targetURL, &contactResponse); err != nil {
+		return nil, err
+	}
+	return contactResponse.Contact, nil
+}
+
+// UpdateContactInfo partial update geoserver global contact information else return error,
+// fields is an optional field mask, a list of contact json field names to send even if they are "" (e.g. "contactVoice"),
+// without fields only the fields set in the contact are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateContactInfo(contact ContactInfo, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "settings", "contact")
+	patch, err := patchEntity(contact, fields)
+	if err != nil {
+		return false, err
+	}
+	return g.updateSettingsEntity(targetURL, map[string]interface{}{"contact": patch})
+}
+
+// GetWorkspaceSettings returns the workspace local settings,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWorkspaceSettings(workspaceName string) (settings *Settings, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "settings")
+	var settingsResponse SettingsRequestBody
+	if err = g.requestResource(targetURL, &settingsResponse); err != nil {
+		return nil, err
+	}
+	return settingsResponse.Settings, nil
+}
+
+// CreateWorkspaceSettings creates the workspace local settings overriding the global ones,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) CreateWorkspaceSettings(workspaceName string, settings Settings) (created bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "settings")
+	return g.createEntity(targetURL, SettingsRequestBody{Settings: &settings}, nil)
+}
+
+// UpdateWorkspaceSettings partial update the workspace local settings else return error,
+// fields is an optional field mask, a list of settings json field names to send even if they are zero or nil (e.g. "numDecimals"),
+// without fields only the fields set in the settings are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWorkspaceSettings(workspaceName string, settings Settings, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "settings")
+	patch, err := patchEntity(settings, fields)
+	if err != nil {
+		return false, err
+	}
+	return g.updateSettingsEntity(targetURL, map[string]interface{}{"settings": patch})
+}
+
+// DeleteWorkspaceSettings deletes the workspace local settings, the workspace falls back to the global settings,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteWorkspaceSettings(workspaceName string) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "settings")
+	return g.deleteEntity(targetURL)
+}
+
+// updateSettingsEntity performs PUT request of the settings entity, geoserver responds 200 on success
+func (g *GeoServer) updateSettingsEntity(targetURL string, data interface{}) (modified bool, err error) {
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalSettings(t *testing.T) {
+	test_before(t)
+	settings, err := gsCatalog.GetGlobalSettings()
+	require.NoError(t, err)
+	require.NotNil(t, settings.Settings)
+	assert.NotEmpty(t, settings.Settings.Charset)
+	original := settings.Settings.ProxyBaseURL
+	defer func() {
+		_, _ = gsCatalog.UpdateGlobalSettings(GlobalSettings{Settings: &Settings{ProxyBaseURL: original, NumDecimals: settings.Settings.NumDecimals}})
+	}()
+
+	modified, err := gsCatalog.UpdateGlobalSettings(GlobalSettings{Settings: &Settings{ProxyBaseURL: "https://maps.example.com/geoserver", NumDecimals: 6}})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	settings, err = gsCatalog.GetGlobalSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "https://maps.example.com/geoserver", settings.Settings.ProxyBaseURL)
+	assert.Equal(t, 6, settings.Settings.NumDecimals)
+
+	_, err = gsCatalog.UpdateGlobalSettings(GlobalSettings{}, "dummyField")
+	assert.NotNil(t, err)
+}
+
+func TestUpdateGlobalSettingsMerge(t *testing.T) {
+	// geoserver replaces the nested settings by the sent ones
+	stored := GlobalSettings{Settings: &Settings{Charset: "UTF-8", NumDecimals: 8, Verbose: BoolPtr(true)}, FeatureTypeCacheSize: 100}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var request GlobalSettingsRequestBody
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+			stored = *request.Global
+		}
+		data, _ := json.Marshal(GlobalSettingsRequestBody{Global: &stored})
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	modified, err := gsCatalog.UpdateGlobalSettings(GlobalSettings{Settings: &Settings{ProxyBaseURL: "https://maps.example.com/geoserver"}})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, GlobalSettings{Settings: &Settings{Charset: "UTF-8", NumDecimals: 8, Verbose: BoolPtr(true),
+		ProxyBaseURL: "https://maps.example.com/geoserver"}, FeatureTypeCacheSize: 100}, stored)
+
+	modified, err = gsCatalog.UpdateGlobalSettings(GlobalSettings{}, "featureTypeCacheSize")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, stored.FeatureTypeCacheSize)
+	assert.Equal(t, 8, stored.Settings.NumDecimals)
+}
+
+func TestContactInfo(t *testing.T) {
+	test_before(t)
+	contact, err := gsCatalog.GetContactInfo()
+	assert.Nil(t, err)
+	assert.NotNil(t, contact)
+	defer func() {
+		_, _ = gsCatalog.UpdateContactInfo(*contact, "contactPerson")
+	}()
+
+	modified, err := gsCatalog.UpdateContactInfo(ContactInfo{ContactPerson: "GIS Team"})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	updated, err := gsCatalog.GetContactInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, "GIS Team", updated.ContactPerson)
+}
+
+func TestWorkspaceSettings(t *testing.T) {
+	test_before(t)
+	_, err := gsCatalog.CreateWorkspace(testWorkspace)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		assert.Fail(t, "can't create workspace as a precondition for workspace settings test")
+	}
+	defer func() {
+		_, _ = gsCatalog.DeleteWorkspace(testWorkspace, true)
+	}()
+
+	created, err := gsCatalog.CreateWorkspaceSettings(testWorkspace, Settings{Charset: "UTF-8", NumDecimals: 4, Contact: &ContactInfo{ContactOrganization: "Example"}})
+	assert.True(t, created)
+	assert.Nil(t, err)
+	settings, err := gsCatalog.GetWorkspaceSettings(testWorkspace)
+	require.NoError(t, err)
+	assert.Equal(t, 4, settings.NumDecimals)
+	assert.Equal(t, "Example", settings.Contact.ContactOrganization)
+
+	modified, err := gsCatalog.UpdateWorkspaceSettings(testWorkspace, Settings{VerboseExceptions: BoolPtr(true)})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	settings, err = gsCatalog.GetWorkspaceSettings(testWorkspace)
+	require.NoError(t, err)
+	assert.True(t, *settings.VerboseExceptions)
+	assert.Equal(t, 4, settings.NumDecimals)
+
+	deleted, err := gsCatalog.DeleteWorkspaceSettings(testWorkspace)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	settings, err = gsCatalog.GetWorkspaceSettings(testWorkspace + "_dummy")
+	assert.Nil(t, settings)
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementSettingsService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	SettingsServiceType := reflect.TypeOf((*SettingsService)(nil)).Elem()
+	check := gsCatalog.Implements(SettingsServiceType)
+	assert.True(t, check)
+}
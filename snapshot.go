@@ -0,0 +1,464 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	SnapshotFormatYAML = "yaml"
+	SnapshotFormatJSON = "json"
+)
+
+// snapshotFile is the name of the snapshot manifest file without the extension
+const snapshotFile = "snapshot"
+
+// SnapshotMapping adapts the snapshot to the target server on restore,
+// Workspaces maps the snapshot workspace names to the target ones,
+// Connections overrides the datastore connection parameters, the keys are the datastores qualified
+// with the snapshot workspace names as ${workspace}:${datastore}
+type SnapshotMapping struct {
+	Workspaces  map[string]string            `yaml:"workspaces,omitempty" json:"workspaces,omitempty"`
+	Connections map[string]map[string]string `yaml:"connections,omitempty" json:"connections,omitempty"`
+}
+
+// ExportManifest describes the workspaces content as the manifest, all workspaces and global styles
+// are exported if workspaces aren't given, otherwise only global styles used by the exported layers and layergroups are included,
+// only datastores and layers published from them are supported, skipped lists the catalog entries which aren't exported
+// (e.g. "coveragestore ws:dem", "layer ws:dem", "layergroup ws:base" publishing a layer or a layergroup which isn't exported),
+// datastore passwords are exported in the encrypted form and should be overridden on restore
+func (g *GeoServer) ExportManifest(workspaces ...string) (manifest *Manifest, skipped []string, err error) {
+	manifest = &Manifest{}
+	wholeCatalog := len(workspaces) == 0
+	if wholeCatalog {
+		liveWorkspaces, err := g.GetWorkspaces()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ws := range liveWorkspaces {
+			workspaces = append(workspaces, ws.Name)
+		}
+	}
+	exported := make(map[string]bool, len(workspaces))
+	for _, name := range workspaces {
+		ws, wsSkipped, err := g.exportWorkspace(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't export workspace %s: %v", name, err)
+		}
+		skipped = append(skipped, wsSkipped...)
+		manifest.Workspaces = append(manifest.Workspaces, ws)
+		exported[name] = true
+	}
+	skipped = append(skipped, manifest.skipUnresolvedLayerGroups()...)
+	sort.Strings(skipped)
+
+	globalStyles, err := g.GetStyles("")
+	if err != nil {
+		return nil, nil, err
+	}
+	used := manifest.globalStyleRefs()
+	for _, s := range globalStyles {
+		if !wholeCatalog && !used[s.Name] {
+			continue
+		}
+		style, err := g.exportStyle("", s.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		manifest.Styles = append(manifest.Styles, style)
+	}
+
+	rules, err := g.GetLayersAclRules()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range rules {
+		if wholeCatalog || exported[r.Workspace] {
+			manifest.ACL = append(manifest.ACL, ManifestACLRule{Workspace: r.Workspace, Layer: r.Layer, Operation: string(r.Operation), Roles: r.Roles})
+		}
+	}
+	return manifest, skipped, nil
+}
+
+func (g *GeoServer) exportWorkspace(workspaceName string) (ws ManifestWorkspace, skipped []string, err error) {
+	workspace, err := g.GetWorkspace(workspaceName)
+	if err != nil {
+		return
+	}
+	ws = ManifestWorkspace{Name: workspaceName, Isolated: workspace.Isolated}
+
+	styles, err := g.GetStyles(workspaceName)
+	if err != nil {
+		return
+	}
+	for _, s := range styles {
+		_, name := splitQualifiedName(s.Name)
+		style, err := g.exportStyle(workspaceName, name)
+		if err != nil {
+			return ws, nil, err
+		}
+		ws.Styles = append(ws.Styles, style)
+	}
+
+	datastores, err := g.GetDatastores(workspaceName)
+	if err != nil {
+		return
+	}
+	for _, ds := range datastores {
+		datastore, err := g.GetDatastoreDetails(workspaceName, ds.Name)
+		if err != nil {
+			return ws, nil, err
+		}
+		md := ManifestDatastore{Name: ds.Name, Connection: map[string]string{}}
+		if !datastore.Enabled {
+			md.Enabled = BoolPtr(false)
+		}
+		for _, e := range datastore.ConnectionParameters.Entry {
+			// the namespace is assigned by the target workspace
+			if e.Key != "namespace" {
+				md.Connection[e.Key] = e.Value
+			}
+		}
+		ws.Datastores = append(ws.Datastores, md)
+	}
+
+	for storeType, getStores := range map[string]func(string) ([]*Resource, error){
+		"coveragestore": g.GetCoverageStores,
+		"wmsstore":      g.GetWMSStores,
+		"wmtsstore":     g.GetWMTSStores,
+	} {
+		stores, err := getStores(workspaceName)
+		if err != nil {
+			return ws, nil, err
+		}
+		for _, store := range stores {
+			skipped = append(skipped, storeType+" "+qualifyName(workspaceName, store.Name))
+		}
+	}
+
+	layers, err := g.GetLayers(workspaceName)
+	if err != nil {
+		return
+	}
+	for _, l := range layers {
+		_, name := splitQualifiedName(l.Name)
+		layer, err := g.exportLayer(workspaceName, name)
+		if err != nil {
+			return ws, nil, err
+		}
+		if layer != nil {
+			ws.Layers = append(ws.Layers, *layer)
+		} else {
+			skipped = append(skipped, "layer "+qualifyName(workspaceName, name))
+		}
+	}
+
+	groups, err := g.GetLayerGroups(workspaceName)
+	if err != nil {
+		return
+	}
+	for _, lg := range groups {
+		layerGroup, err := g.GetLayerGroup(workspaceName, lg.Name)
+		if err != nil {
+			return ws, nil, err
+		}
+		ws.LayerGroups = append(ws.LayerGroups, exportLayerGroup(layerGroup))
+	}
+	return ws, skipped, nil
+}
+
+// skipUnresolvedLayerGroups removes the layergroups publishing the layers or the layergroups the manifest doesn't describe
+// (skipped layers, global publishables, publishables of the workspaces not exported and removed layergroups),
+// returns the removed layergroups
+func (m *Manifest) skipUnresolvedLayerGroups() (skipped []string) {
+	for removed := true; removed; {
+		removed = false
+		described := make(map[string]bool)
+		for _, ws := range m.Workspaces {
+			for _, l := range ws.Layers {
+				described[qualifyName(ws.Name, l.Name)] = true
+			}
+			for _, lg := range ws.LayerGroups {
+				described[qualifyName(ws.Name, lg.Name)] = true
+			}
+		}
+		for i := range m.Workspaces {
+			ws := &m.Workspaces[i]
+			groups := ws.LayerGroups[:0]
+			for _, lg := range ws.LayerGroups {
+				if publishesDescribed(ws.Name, lg, described) {
+					groups = append(groups, lg)
+					continue
+				}
+				skipped = append(skipped, "layergroup "+qualifyName(ws.Name, lg.Name))
+				removed = true
+			}
+			ws.LayerGroups = groups
+		}
+	}
+	return
+}
+
+// publishesDescribed returns true if all publishables of the layergroup of the workspace are described
+func publishesDescribed(workspaceName string, lg ManifestLayerGroup, described map[string]bool) bool {
+	for _, name := range lg.Layers {
+		if !described[qualifyName(workspaceName, name)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *GeoServer) exportStyle(workspaceName string, styleName string) (style ManifestStyle, err error) {
+	body, err := g.GetStyleBody(workspaceName, styleName)
+	if err != nil {
+		return
+	}
+	return ManifestStyle{Name: styleName, Body: string(body)}, nil
+}
+
+// exportLayer describes the layer, nil is returned for the layers not published from a datastore
+func (g *GeoServer) exportLayer(workspaceName string, layerName string) (*ManifestLayer, error) {
+	layer, err := g.GetLayer(workspaceName, layerName)
+	if err != nil {
+		return nil, err
+	}
+	datastoreName, featureTypeName := featureTypeFromHref(layer.Resource.Href)
+	if datastoreName == "" {
+		return nil, nil
+	}
+	featureType, err := g.GetFeatureType(workspaceName, datastoreName, featureTypeName)
+	if err != nil {
+		return nil, err
+	}
+	ml := &ManifestLayer{
+		Name:      layerName,
+		Datastore: datastoreName,
+		Title:     featureType.Title,
+		Abstract:  featureType.Abstract,
+		Srs:       featureType.Srs,
+		Enabled:   layer.Enabled,
+	}
+	if featureType.NativeName != layerName {
+		ml.NativeName = featureType.NativeName
+	}
+	if layer.DefaultStyle != nil {
+		ml.DefaultStyle = layer.DefaultStyle.Name
+	}
+	if layer.Styles != nil {
+		for _, s := range layer.Styles.Style {
+			ml.Styles = append(ml.Styles, s.Name)
+		}
+	}
+
+	gwcLayer, err := g.GetGwcLayer(workspaceName, layerName)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil {
+		gwc := &ManifestGWC{Enabled: BoolPtr(gwcLayer.Enabled), Formats: gwcLayer.MimeFormats, MetaTiling: gwcLayer.MetaWidthHeight, ExpireCache: gwcLayer.ExpireCache}
+		for _, s := range gwcLayer.GridSubsets {
+			gwc.GridSets = append(gwc.GridSets, s.GridSetName)
+		}
+		ml.GWC = gwc
+	}
+	return ml, nil
+}
+
+func exportLayerGroup(layerGroup *LayerGroup) ManifestLayerGroup {
+	mlg := ManifestLayerGroup{Name: layerGroup.Name, Mode: layerGroup.Mode, Title: layerGroup.Title, Abstract: layerGroup.Abstract, Layers: []string{}}
+	styles := make([]string, 0, len(layerGroup.Publishables.Published))
+	customStyles := false
+	for i, item := range layerGroup.Publishables.Published {
+		mlg.Layers = append(mlg.Layers, item.Name)
+		style := ""
+		if i < len(layerGroup.Styles.Style) && layerGroup.Styles.Style[i] != nil {
+			style = layerGroup.Styles.Style[i].Name
+			customStyles = true
+		}
+		styles = append(styles, style)
+	}
+	if customStyles {
+		mlg.Styles = styles
+	}
+	return mlg
+}
+
+// globalStyleRefs returns the set of unqualified style names referenced by the workspaces layers and layergroups
+func (m *Manifest) globalStyleRefs() map[string]bool {
+	refs := make(map[string]bool)
+	add := func(workspace ManifestWorkspace, name string) {
+		if name != "" && !strings.Contains(name, ":") && workspace.qualifyStyle(name) == name {
+			refs[name] = true
+		}
+	}
+	for _, ws := range m.Workspaces {
+		for _, l := range ws.Layers {
+			add(ws, l.DefaultStyle)
+			for _, s := range l.Styles {
+				add(ws, s)
+			}
+		}
+		for _, lg := range ws.LayerGroups {
+			for _, s := range lg.Styles {
+				add(ws, s)
+			}
+		}
+	}
+	return refs
+}
+
+// ExportSnapshot exports the workspaces (whole catalog if workspaces aren't given) to the snapshot directory,
+// skipped lists the catalog entries which aren't exported, see ExportManifest and WriteSnapshot
+func (g *GeoServer) ExportSnapshot(dir string, format string, workspaces ...string) (skipped []string, err error) {
+	manifest, skipped, err := g.ExportManifest(workspaces...)
+	if err != nil {
+		return nil, err
+	}
+	return skipped, WriteSnapshot(manifest, dir, format)
+}
+
+// WriteSnapshot writes the manifest to the directory as snapshot.yaml or snapshot.json (format is one of SnapshotFormat* constants),
+// inline style bodies are moved to styles/${style}.sld and styles/${workspace}/${style}.sld files
+func WriteSnapshot(manifest *Manifest, dir string, format string) error {
+	snapshot := *manifest
+	var err error
+	if snapshot.Styles, err = writeSnapshotStyles(dir, "", manifest.Styles); err != nil {
+		return err
+	}
+	snapshot.Workspaces = make([]ManifestWorkspace, 0, len(manifest.Workspaces))
+	for _, ws := range manifest.Workspaces {
+		if ws.Styles, err = writeSnapshotStyles(dir, ws.Name, ws.Styles); err != nil {
+			return err
+		}
+		snapshot.Workspaces = append(snapshot.Workspaces, ws)
+	}
+
+	var data []byte
+	switch format {
+	case SnapshotFormatYAML:
+		data, err = snapshot.Marshal()
+	case SnapshotFormatJSON:
+		data, err = json.MarshalIndent(snapshot, "", "  ")
+	default:
+		return fmt.Errorf("unknown snapshot format %s", format)
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, snapshotFile+"."+format), data, 0644)
+}
+
+func writeSnapshotStyles(dir string, workspaceName string, styles []ManifestStyle) ([]ManifestStyle, error) {
+	written := make([]ManifestStyle, 0, len(styles))
+	for _, s := range styles {
+		if s.Body != "" {
+			file := filepath.Join("styles", workspaceName, s.Name+".sld")
+			if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755); err != nil {
+				return nil, err
+			}
+			if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(s.Body), 0644); err != nil {
+				return nil, err
+			}
+			s = ManifestStyle{Name: s.Name, File: filepath.ToSlash(file)}
+		}
+		written = append(written, s)
+	}
+	return written, nil
+}
+
+// LoadSnapshot loads the snapshot written by WriteSnapshot from the directory
+func LoadSnapshot(dir string) (manifest *Manifest, err error) {
+	for _, format := range []string{SnapshotFormatYAML, SnapshotFormatJSON} {
+		file := filepath.Join(dir, snapshotFile+"."+format)
+		if _, err = os.Stat(file); err == nil {
+			return LoadManifest(file)
+		}
+	}
+	return nil, fmt.Errorf("snapshot isn't found in %s", dir)
+}
+
+// Remap returns the copy of the manifest with the workspaces renamed and the datastore connection parameters overridden
+func (m *Manifest) Remap(mapping SnapshotMapping) (*Manifest, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	remapped := &Manifest{}
+	if err = yaml.Unmarshal(data, remapped); err != nil {
+		return nil, err
+	}
+	remapped.baseDir = m.baseDir
+
+	rename := func(name string) string {
+		workspaceName, localName := splitQualifiedName(name)
+		if target, ok := mapping.Workspaces[workspaceName]; ok && workspaceName != "" {
+			return qualifyName(target, localName)
+		}
+		return name
+	}
+	for i := range remapped.Workspaces {
+		ws := &remapped.Workspaces[i]
+		for j := range ws.Datastores {
+			ds := &ws.Datastores[j]
+			for key, value := range mapping.Connections[qualifyName(ws.Name, ds.Name)] {
+				if ds.Connection == nil {
+					ds.Connection = map[string]string{}
+				}
+				ds.Connection[key] = value
+			}
+		}
+		for j := range ws.Layers {
+			l := &ws.Layers[j]
+			l.DefaultStyle = rename(l.DefaultStyle)
+			for k := range l.Styles {
+				l.Styles[k] = rename(l.Styles[k])
+			}
+		}
+		for j := range ws.LayerGroups {
+			lg := &ws.LayerGroups[j]
+			for k := range lg.Layers {
+				lg.Layers[k] = rename(lg.Layers[k])
+			}
+			for k := range lg.Styles {
+				lg.Styles[k] = rename(lg.Styles[k])
+			}
+		}
+		if target, ok := mapping.Workspaces[ws.Name]; ok {
+			ws.Name = target
+		}
+	}
+	for i := range remapped.ACL {
+		if target, ok := mapping.Workspaces[remapped.ACL[i].Workspace]; ok {
+			remapped.ACL[i].Workspace = target
+		}
+	}
+	if err = remapped.Validate(); err != nil {
+		return nil, err
+	}
+	return remapped, nil
+}
+
+// ImportSnapshot restores the snapshot from the directory applying the mapping,
+// plan contains the changes made (or planned if err isn't nil), applied is the number of changes successfully applied
+func (g *GeoServer) ImportSnapshot(dir string, mapping SnapshotMapping) (plan *ManifestPlan, applied int, err error) {
+	manifest, err := LoadSnapshot(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if manifest, err = manifest.Remap(mapping); err != nil {
+		return nil, 0, err
+	}
+	if plan, err = g.Plan(manifest); err != nil {
+		return nil, 0, err
+	}
+	applied, err = g.Apply(plan)
+	return plan, applied, err
+}
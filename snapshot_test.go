@@ -0,0 +1,128 @@
+package geoserver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRemap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	manifest, err := ParseManifest([]byte(testManifest), ".")
+	assert.Nil(t, err)
+	manifest.Workspaces[0].Layers[0].Styles = []string{"parks:park", "line"}
+	assert.Nil(t, WriteSnapshot(manifest, dir, SnapshotFormatJSON))
+	body, err := ioutil.ReadFile(filepath.Join(dir, "styles", "parks", "park.sld"))
+	assert.Nil(t, err)
+	assert.Equal(t, "<StyledLayerDescriptor/>", string(body))
+
+	snapshot, err := LoadSnapshot(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "styles/parks/park.sld", snapshot.Workspaces[0].Styles[0].File)
+	styleBody, err := snapshot.styleBody(snapshot.Workspaces[0].Styles[0])
+	assert.Nil(t, err)
+	assert.Equal(t, body, styleBody)
+
+	remapped, err := snapshot.Remap(SnapshotMapping{
+		Workspaces:  map[string]string{"parks": "parks_prod"},
+		Connections: map[string]map[string]string{"parks:pg": {"host": "db.prod", "passwd": "prod"}},
+	})
+	assert.Nil(t, err)
+	ws := remapped.Workspaces[0]
+	assert.Equal(t, "parks_prod", ws.Name)
+	assert.Equal(t, "db.prod", ws.Datastores[0].Connection["host"])
+	assert.Equal(t, "postgis", ws.Datastores[0].Connection["dbtype"])
+	assert.Equal(t, []string{"parks_prod:park", "line"}, ws.Layers[0].Styles)
+	assert.Equal(t, "parks_prod", remapped.ACL[0].Workspace)
+	assert.Equal(t, "parks", snapshot.Workspaces[0].Name)
+	assert.Equal(t, "localhost", snapshot.Workspaces[0].Datastores[0].Connection["host"])
+
+	_, err = LoadSnapshot(filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestExportSnapshot(t *testing.T) {
+	test_before(t)
+	featureTypePrecondition(t)
+	defer featureTypePostcondition()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	skipped, err := gsCatalog.ExportSnapshot(dir, SnapshotFormatYAML, testWorkspace)
+	assert.Nil(t, err)
+	assert.Empty(t, skipped)
+	snapshot, err := LoadSnapshot(dir)
+	assert.Nil(t, err)
+	assert.Len(t, snapshot.Workspaces, 1)
+	assert.Equal(t, testWorkspace, snapshot.Workspaces[0].Name)
+	assert.NotEmpty(t, snapshot.Workspaces[0].Datastores)
+
+	plan, err := gsCatalog.Plan(snapshot)
+	assert.Nil(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+}
+
+func TestExportManifestSkipped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces/ws":
+			w.Write([]byte(`{"workspace":{"name":"ws"}}`))
+		case "/rest/workspaces/ws/styles", "/rest/styles":
+			w.Write([]byte(`{"styles":""}`))
+		case "/rest/workspaces/ws/datastores":
+			w.Write([]byte(`{"dataStores":{"dataStore":[{"name":"pg"}]}}`))
+		case "/rest/workspaces/ws/datastores/pg":
+			w.Write([]byte(`{"dataStore":{"name":"pg","enabled":true,"connectionParameters":{"entry":[{"@key":"host","$":"db"}]}}}`))
+		case "/rest/workspaces/ws/datastores/pg/featuretypes/roads":
+			w.Write([]byte(`{"featureType":{"name":"roads","nativeName":"roads","srs":"EPSG:4326"}}`))
+		case "/rest/workspaces/ws/coveragestores":
+			w.Write([]byte(`{"coverageStores":{"coverageStore":[{"name":"dem"}]}}`))
+		case "/rest/workspaces/ws/wmsstores":
+			w.Write([]byte(`{"wmsStores":""}`))
+		case "/rest/workspaces/ws/wmtsstores":
+			w.Write([]byte(`{"wmtsStores":{"wmtsStore":[{"name":"tiles"}]}}`))
+		case "/rest/workspaces/ws/layers":
+			w.Write([]byte(`{"layers":{"layer":[{"name":"roads"},{"name":"dem"}]}}`))
+		case "/rest/workspaces/ws/layers/roads":
+			w.Write([]byte(`{"layer":{"name":"roads","enabled":true,"resource":{"@class":"featureType","name":"ws:roads","href":"http://localhost/rest/workspaces/ws/datastores/pg/featuretypes/roads.json"}}}`))
+		case "/rest/workspaces/ws/layers/dem":
+			w.Write([]byte(`{"layer":{"name":"dem","enabled":true,"resource":{"@class":"coverage","name":"ws:dem","href":"http://localhost/rest/workspaces/ws/coveragestores/dem/coverages/dem.json"}}}`))
+		case "/rest/workspaces/ws/layergroups":
+			w.Write([]byte(`{"layerGroups":{"layerGroup":[{"name":"base"},{"name":"streets"},{"name":"all"},{"name":"shared"}]}}`))
+		case "/rest/workspaces/ws/layergroups/base":
+			w.Write([]byte(`{"layerGroup":{"name":"base","mode":"SINGLE","publishables":{"published":[{"@type":"layer","name":"ws:dem"},{"@type":"layer","name":"ws:roads"}]}}}`))
+		case "/rest/workspaces/ws/layergroups/streets":
+			w.Write([]byte(`{"layerGroup":{"name":"streets","mode":"SINGLE","publishables":{"published":[{"@type":"layer","name":"ws:roads"}]}}}`))
+		case "/rest/workspaces/ws/layergroups/all":
+			w.Write([]byte(`{"layerGroup":{"name":"all","mode":"NAMED","publishables":{"published":[{"@type":"layerGroup","name":"ws:streets"},{"@type":"layerGroup","name":"ws:base"}]}}}`))
+		case "/rest/workspaces/ws/layergroups/shared":
+			w.Write([]byte(`{"layerGroup":{"name":"shared","mode":"SINGLE","publishables":{"published":[{"@type":"layer","name":"ws:roads"},{"@type":"layer","name":"other:rivers"}]}}}`))
+		case "/rest/security/acl/layers":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	manifest, skipped, err := gsCatalog.ExportManifest("ws")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"coveragestore ws:dem", "layer ws:dem", "layergroup ws:all", "layergroup ws:base", "layergroup ws:shared", "wmtsstore ws:tiles"}, skipped)
+	assert.Len(t, manifest.Workspaces, 1)
+	assert.Len(t, manifest.Workspaces[0].Datastores, 1)
+	assert.Len(t, manifest.Workspaces[0].Layers, 1)
+	assert.Equal(t, "roads", manifest.Workspaces[0].Layers[0].Name)
+	assert.Len(t, manifest.Workspaces[0].LayerGroups, 1)
+	assert.Equal(t, "streets", manifest.Workspaces[0].LayerGroups[0].Name)
+}
@@ -0,0 +1,175 @@
+package geoserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	StyleUserLayer      = "layer"      //style is referenced by a layer
+	StyleUserLayerGroup = "layerGroup" //style is referenced by a layergroup
+)
+
+// StyleUser describes a layer or a layergroup referencing a style,
+// Type is one of StyleUserLayer, StyleUserLayerGroup,
+// Default is true if the style is the default style of the layer or the root layer style of the EO layergroup
+type StyleUser struct {
+	Type      string
+	Workspace string
+	Name      string
+	Default   bool
+}
+
+// StyleUsage maps a style name to the list of the layers and layergroups referencing it,
+// workspace styles are qualified as ${workspace}:${style}
+type StyleUsage map[string][]StyleUser
+
+// Users returns the list of the layers and layergroups referencing the style,
+// styleName can be qualified with the style workspace as ${workspace}:${style}
+func (u StyleUsage) Users(styleName string) []StyleUser {
+	return u[styleName]
+}
+
+// InUse returns true if the style is referenced by any layer or layergroup
+func (u StyleUsage) InUse(styleName string) bool {
+	return len(u.Users(styleName)) != 0
+}
+
+func (u StyleUsage) add(styleName string, user StyleUser) {
+	if styleName == "" {
+		return
+	}
+	u[styleName] = append(u[styleName], user)
+}
+
+// StyleInUseError is returned when the style can't be deleted because it's still referenced
+type StyleInUseError struct {
+	Style string
+	Users []StyleUser
+}
+
+func (e StyleInUseError) Error() string {
+	names := make([]string, 0, len(e.Users))
+	for _, u := range e.Users {
+		names = append(names, fmt.Sprintf("%s %s", u.Type, qualifiedStyleName(u.Workspace, u.Name)))
+	}
+	return fmt.Sprintf("style %s is in use by %s", e.Style, strings.Join(names, ", "))
+}
+
+func qualifiedStyleName(workspaceName string, name string) string {
+	if workspaceName == "" {
+		return name
+	}
+	return fmt.Sprintf("%s:%s", workspaceName, name)
+}
+
+// GetStyleUsage scans all layers and layergroups (global and per workspace) and returns the styles usage map
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetStyleUsage() (usage StyleUsage, err error) {
+	usage = StyleUsage{}
+
+	layers, err := g.GetLayers("")
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range layers {
+		layer, err := g.GetLayer("", l.Name)
+		if err != nil {
+			return nil, err
+		}
+		workspaceName, name := splitQualifiedName(l.Name)
+		if layer.DefaultStyle != nil {
+			usage.add(layer.DefaultStyle.Name, StyleUser{Type: StyleUserLayer, Workspace: workspaceName, Name: name, Default: true})
+		}
+		if layer.Styles != nil {
+			for _, s := range layer.Styles.Style {
+				usage.add(s.Name, StyleUser{Type: StyleUserLayer, Workspace: workspaceName, Name: name})
+			}
+		}
+	}
+
+	workspaceNames, err := g.scopeNames()
+	if err != nil {
+		return nil, err
+	}
+	for _, workspaceName := range workspaceNames {
+		groups, err := g.GetLayerGroups(workspaceName)
+		if err != nil {
+			return nil, err
+		}
+		for _, gr := range groups {
+			group, err := g.GetLayerGroup(workspaceName, gr.Name)
+			if err != nil {
+				return nil, err
+			}
+			if group.RootLayerStyle != nil {
+				usage.add(group.RootLayerStyle.Name, StyleUser{Type: StyleUserLayerGroup, Workspace: workspaceName, Name: gr.Name, Default: true})
+			}
+			for _, s := range group.Styles.Style {
+				if s != nil {
+					usage.add(s.Name, StyleUser{Type: StyleUserLayerGroup, Workspace: workspaceName, Name: gr.Name})
+				}
+			}
+		}
+	}
+	return
+}
+
+// GetOrphanStyles returns the names of the styles (global and per workspace) which aren't referenced by any layer or layergroup,
+// workspace styles are qualified as ${workspace}:${style}
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetOrphanStyles() (styles []string, err error) {
+	usage, err := g.GetStyleUsage()
+	if err != nil {
+		return nil, err
+	}
+	workspaceNames, err := g.scopeNames()
+	if err != nil {
+		return nil, err
+	}
+
+	styles = make([]string, 0)
+	for _, workspaceName := range workspaceNames {
+		workspaceStyles, err := g.GetStyles(workspaceName)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range workspaceStyles {
+			name := qualifiedStyleName(workspaceName, s.Name)
+			if !usage.InUse(name) {
+				styles = append(styles, name)
+			}
+		}
+	}
+	sort.Strings(styles)
+	return
+}
+
+// DeleteUnusedStyle deletes geoserver style only if it isn't referenced by any layer or layergroup,
+// if the style is in use, StyleInUseError is returned,
+// if workspace is "" will delete geoserver public style
+func (g *GeoServer) DeleteUnusedStyle(workspaceName string, styleName string, purge bool) (deleted bool, err error) {
+	usage, err := g.GetStyleUsage()
+	if err != nil {
+		return false, err
+	}
+	name := qualifiedStyleName(workspaceName, styleName)
+	if users := usage.Users(name); len(users) != 0 {
+		return false, StyleInUseError{Style: name, Users: users}
+	}
+	return g.DeleteStyle(workspaceName, styleName, purge)
+}
+
+// scopeNames returns "" for the global scope followed by the names of all workspaces
+func (g *GeoServer) scopeNames() (names []string, err error) {
+	workspaces, err := g.GetWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	names = []string{""}
+	for _, w := range workspaces {
+		names = append(names, w.Name)
+	}
+	return
+}
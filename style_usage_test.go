@@ -0,0 +1,37 @@
+package geoserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleUsage(t *testing.T) {
+	usage := StyleUsage{}
+	usage.add("polygon", StyleUser{Type: StyleUserLayer, Workspace: "tiger", Name: "poly_landmarks", Default: true})
+	usage.add("tiger:poi", StyleUser{Type: StyleUserLayerGroup, Workspace: "tiger", Name: "tiger-ny"})
+	usage.add("", StyleUser{Type: StyleUserLayer, Workspace: "tiger", Name: "poi"})
+	assert.True(t, usage.InUse("polygon"))
+	assert.True(t, usage.InUse("tiger:poi"))
+	assert.False(t, usage.InUse("poi"))
+	assert.Len(t, usage, 2)
+	err := StyleInUseError{Style: "polygon", Users: usage.Users("polygon")}
+	assert.Equal(t, "style polygon is in use by layer tiger:poly_landmarks", err.Error())
+}
+
+func TestGetStyleUsage(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	usage, err := gsCatalog.GetStyleUsage()
+	assert.Nil(t, err)
+	assert.True(t, usage.InUse("polygon"))
+	orphans, err := gsCatalog.GetOrphanStyles()
+	assert.Nil(t, err)
+	assert.NotNil(t, orphans)
+	deleted, err := gsCatalog.DeleteUnusedStyle("", "polygon", false)
+	assert.False(t, deleted)
+	assert.IsType(t, StyleInUseError{}, err)
+	gsCatalog = GetCatalog("http://localhost:8080/geoserver_dummy/", "admin", "geoserver")
+	usage, err = gsCatalog.GetStyleUsage()
+	assert.Nil(t, usage)
+	assert.NotNil(t, err)
+}
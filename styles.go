@@ -10,6 +10,7 @@ import (
 // StyleService define all geoserver style operations
 type StyleService interface {
 	GetStyles(workspaceName string) (styles []*Resource, err error)
+	IterateStyles(workspaceName string, options IteratorOptions) (*ResourceIterator, error)
 
 	CreateStyle(workspaceName string, styleName string) (created bool, err error)
 
@@ -20,6 +21,8 @@ type StyleService interface {
 	GetStyle(workspaceName string, styleName string) (style *Style, err error)
 
 	StyleExists(workspaceName string, styleName string) (exists bool, err error)
+
+	GetStyleBody(workspaceName string, styleName string) (body []byte, err error)
 }
 
 //LanguageVersion style version
@@ -101,6 +104,29 @@ func (g *GeoServer) GetStyle(workspaceName string, styleName string) (style *Sty
 	return
 }
 
+//GetStyleBody returns the style definition (SLD) of geoserver style,
+//if workspace is "" will return non-workspce style body
+func (g *GeoServer) GetStyleBody(workspaceName string, styleName string) (body []byte, err error) {
+	if workspaceName != "" {
+		workspaceName = fmt.Sprintf("workspaces/%s/", workspaceName)
+	}
+	targetURL := g.ParseURL("rest", workspaceName, "styles", styleName)
+	httpRequest := HTTPRequest{
+		Method: getMethod,
+		Accept: sldType,
+		URL:    targetURL,
+		Query:  nil,
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk {
+		g.logger.Error(string(response))
+		err = g.GetError(responseCode, response)
+		return
+	}
+	body = response
+	return
+}
+
 //StyleExists return true if style exists in geoserver
 func (g *GeoServer) StyleExists(workspaceName string, styleName string) (exists bool, err error) {
 	_, styleErr := g.GetStyle(workspaceName, styleName)
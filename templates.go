@@ -0,0 +1,175 @@
+package geoserver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	TemplateLevelFeatureType = "featureType" //template is defined for the feature type
+	TemplateLevelStore       = "store"       //template is defined for the datastore
+	TemplateLevelWorkspace   = "workspace"   //template is defined for the workspace
+	TemplateLevelGlobal      = "global"      //template is defined globally
+	TemplateLevelDefault     = "default"     //no custom template, geoserver built-in template is used
+)
+
+// TemplateService define geoserver freemarker templates operations,
+// templates are managed at global level if workspaceName is "",
+// at workspace level if datastoreName is "", at datastore level if featureTypeName is "", else at feature type level
+type TemplateService interface {
+	GetTemplates(workspaceName string, datastoreName string, featureTypeName string) (templates []*Resource, err error)
+	GetTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string) (content []byte, err error)
+	UploadTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string, content []byte) (uploaded bool, err error)
+	DeleteTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string) (deleted bool, err error)
+	ResolveTemplate(workspaceName string, layerName string, templateName string) (location TemplateLocation, err error)
+}
+
+// TemplateLocation is the place the template is taken from, Level is one of TemplateLevel* constants,
+// the names not relevant to the Level are empty
+type TemplateLocation struct {
+	Level       string
+	Workspace   string
+	Datastore   string
+	FeatureType string
+}
+
+// templatesURL returns the templates url of the level defined by the non empty names
+func (g *GeoServer) templatesURL(workspaceName string, datastoreName string, featureTypeName string, templateName string) string {
+	parts := []string{"rest"}
+	if workspaceName != "" {
+		parts = append(parts, "workspaces", workspaceName)
+		if datastoreName != "" {
+			parts = append(parts, "datastores", datastoreName)
+			if featureTypeName != "" {
+				parts = append(parts, "featuretypes", featureTypeName)
+			}
+		}
+	}
+	parts = append(parts, "templates")
+	if templateName != "" {
+		if !strings.HasSuffix(templateName, ".ftl") {
+			templateName += ".ftl"
+		}
+		parts = append(parts, templateName)
+	}
+	return g.ParseURL(parts...)
+}
+
+// GetTemplates returns the templates defined at the level as resources,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetTemplates(workspaceName string, datastoreName string, featureTypeName string) (templates []*Resource, err error) {
+	return g.requestResourceList(g.templatesURL(workspaceName, datastoreName, featureTypeName, ""), nil, "templates", "template")
+}
+
+// GetTemplate returns the template content, templateName can be given with or without .ftl extension,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string) (content []byte, err error) {
+	httpRequest := HTTPRequest{
+		Method: getMethod,
+		Accept: "text/plain",
+		URL:    g.templatesURL(workspaceName, datastoreName, featureTypeName, templateName),
+		Query:  nil,
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk {
+		g.logger.Error(string(response))
+		return nil, g.GetError(responseCode, response)
+	}
+	return response, nil
+}
+
+// UploadTemplate creates or overwrites the template, templateName can be given with or without .ftl extension,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) UploadTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string, content []byte) (uploaded bool, err error) {
+	httpRequest := HTTPRequest{
+		Method:   putMethod,
+		Accept:   jsonType,
+		Data:     bytes.NewBuffer(content),
+		DataType: "text/plain",
+		URL:      g.templatesURL(workspaceName, datastoreName, featureTypeName, templateName),
+		Query:    nil,
+	}
+	response, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusCreated && responseCode != statusOk {
+		g.logger.Error(string(response))
+		return false, g.GetError(responseCode, response)
+	}
+	return true, nil
+}
+
+// DeleteTemplate deletes the template, templateName can be given with or without .ftl extension,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteTemplate(workspaceName string, datastoreName string, featureTypeName string, templateName string) (deleted bool, err error) {
+	return g.deleteEntity(g.templatesURL(workspaceName, datastoreName, featureTypeName, templateName))
+}
+
+// ResolveTemplate returns the location of the template file geoserver uses for the layer,
+// the lookup order is feature type, datastore, workspace, global, if the template isn't found
+// TemplateLevelDefault is returned meaning geoserver uses its built-in template,
+// the datastore and feature type levels are checked only for the vector layers
+func (g *GeoServer) ResolveTemplate(workspaceName string, layerName string, templateName string) (location TemplateLocation, err error) {
+	if !strings.HasSuffix(templateName, ".ftl") {
+		templateName += ".ftl"
+	}
+	layer, err := g.GetLayer(workspaceName, layerName)
+	if err != nil {
+		return location, err
+	}
+	if workspaceName == "" {
+		workspaceName, _ = splitQualifiedName(layerName)
+	}
+	datastoreName, featureTypeName := featureTypeFromHref(layer.Resource.Href)
+
+	candidates := []TemplateLocation{
+		{Level: TemplateLevelFeatureType, Workspace: workspaceName, Datastore: datastoreName, FeatureType: featureTypeName},
+		{Level: TemplateLevelStore, Workspace: workspaceName, Datastore: datastoreName},
+		{Level: TemplateLevelWorkspace, Workspace: workspaceName},
+		{Level: TemplateLevelGlobal},
+	}
+	for _, c := range candidates {
+		if (c.Level == TemplateLevelFeatureType && featureTypeName == "") ||
+			(c.Level == TemplateLevelStore && datastoreName == "") ||
+			(c.Level == TemplateLevelWorkspace && workspaceName == "") {
+			continue
+		}
+		templates, err := g.GetTemplates(c.Workspace, c.Datastore, c.FeatureType)
+		if err != nil && !isNotFoundError(err) {
+			return location, err
+		}
+		for _, t := range templates {
+			if t.Name == templateName {
+				return c, nil
+			}
+		}
+	}
+	return TemplateLocation{Level: TemplateLevelDefault}, nil
+}
+
+// featureTypeFromHref extracts the datastore and the feature type names from the feature type resource href like
+// http://localhost:8080/geoserver/rest/workspaces/ws/datastores/store/featuretypes/name.json,
+// empty names are returned if the href isn't a feature type href
+func featureTypeFromHref(href string) (datastoreName string, featureTypeName string) {
+	parts := strings.Split(href, "/")
+	for i := 0; i+3 < len(parts); i++ {
+		if parts[i] == "datastores" && parts[i+2] == "featuretypes" {
+			return parts[i+1], strings.TrimSuffix(parts[i+3], ".json")
+		}
+	}
+	return "", ""
+}
+
+// String returns the directory of the template location relative to the data directory
+func (l TemplateLocation) String() string {
+	switch l.Level {
+	case TemplateLevelFeatureType:
+		return fmt.Sprintf("workspaces/%s/%s/%s", l.Workspace, l.Datastore, l.FeatureType)
+	case TemplateLevelStore:
+		return fmt.Sprintf("workspaces/%s/%s", l.Workspace, l.Datastore)
+	case TemplateLevelWorkspace:
+		return fmt.Sprintf("workspaces/%s", l.Workspace)
+	case TemplateLevelGlobal:
+		return "templates"
+	}
+	return l.Level
+}
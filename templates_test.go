@@ -0,0 +1,90 @@
+package geoserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates(t *testing.T) {
+	test_before(t)
+	featureTypePrecondition(t)
+	defer featureTypePostcondition()
+	datastoreName, featureTypeName := testDatastore, "museum_nyc"
+
+	content := []byte("<#list features as feature>${feature.fid}</#list>")
+	uploaded, err := gsCatalog.UploadTemplate(testWorkspace, datastoreName, featureTypeName, "content.ftl", content)
+	assert.True(t, uploaded)
+	assert.Nil(t, err)
+	uploaded, err = gsCatalog.UploadTemplate(testWorkspace, "", "", "header", []byte("<html>"))
+	assert.True(t, uploaded)
+	assert.Nil(t, err)
+
+	templates, err := gsCatalog.GetTemplates(testWorkspace, datastoreName, featureTypeName)
+	assert.Nil(t, err)
+	assert.Len(t, templates, 1)
+	fetched, err := gsCatalog.GetTemplate(testWorkspace, datastoreName, featureTypeName, "content")
+	assert.Nil(t, err)
+	assert.Equal(t, content, fetched)
+
+	location, err := gsCatalog.ResolveTemplate(testWorkspace, featureTypeName, "content.ftl")
+	assert.Nil(t, err)
+	assert.Equal(t, TemplateLocation{Level: TemplateLevelFeatureType, Workspace: testWorkspace, Datastore: datastoreName, FeatureType: featureTypeName}, location)
+	location, err = gsCatalog.ResolveTemplate(testWorkspace, featureTypeName, "header.ftl")
+	assert.Nil(t, err)
+	assert.Equal(t, TemplateLevelWorkspace, location.Level)
+
+	deleted, err := gsCatalog.DeleteTemplate(testWorkspace, datastoreName, featureTypeName, "content.ftl")
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	_, err = gsCatalog.GetTemplate(testWorkspace, datastoreName, featureTypeName, "content.ftl")
+	assert.NotNil(t, err)
+}
+
+func TestResolveTemplate(t *testing.T) {
+	var serverURL string
+	responses := map[string]string{
+		"/rest/workspaces/ws/layers/roads":                               `{"layer":{"name":"roads","resource":{"@class":"featureType","name":"ws:roads","href":"` + "%s" + `/rest/workspaces/ws/datastores/pg/featuretypes/roads.json"}}}`,
+		"/rest/workspaces/ws/datastores/pg/featuretypes/roads/templates": `{"templates":""}`,
+		"/rest/workspaces/ws/datastores/pg/templates":                    `{"templates":{"template":{"name":"content.ftl"}}}`,
+		"/rest/workspaces/ws/templates":                                  `{"templates":{"template":[{"name":"header.ftl"},{"name":"footer.ftl"}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/rest/workspaces/ws/layers/roads" {
+			response = fmt.Sprintf(response, serverURL)
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	location, err := gsCatalog.ResolveTemplate("ws", "roads", "content")
+	assert.Nil(t, err)
+	assert.Equal(t, TemplateLocation{Level: TemplateLevelStore, Workspace: "ws", Datastore: "pg"}, location)
+	assert.Equal(t, "workspaces/ws/pg", location.String())
+	location, err = gsCatalog.ResolveTemplate("ws", "roads", "footer.ftl")
+	assert.Nil(t, err)
+	assert.Equal(t, TemplateLevelWorkspace, location.Level)
+	location, err = gsCatalog.ResolveTemplate("ws", "roads", "description.ftl")
+	assert.Nil(t, err)
+	assert.Equal(t, TemplateLevelDefault, location.Level)
+	_, err = gsCatalog.ResolveTemplate("ws", "rivers", "content.ftl")
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementTemplateService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	TemplateServiceType := reflect.TypeOf((*TemplateService)(nil)).Elem()
+	check := gsCatalog.Implements(TemplateServiceType)
+	assert.True(t, check)
+}
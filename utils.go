@@ -11,6 +11,8 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // HTTPRequest is an http request object
@@ -55,12 +57,30 @@ func (g *GeoServer) DoRequest(request HTTPRequest) (responseText []byte, statusC
 		}
 		req.URL.RawQuery = q.Encode()
 	}
+	if statusCode, ok := g.recordMutation(req); ok {
+		return []byte{}, statusCode
+	}
+	cached := g.cache != nil && request.Method == getMethod && cacheable(req.URL.Path)
+	var generation uint64
+	if g.cache != nil && request.Method != getMethod {
+		// the entries are invalidated before the write as well to not serve them during the write
+		g.cache.Invalidate(req.URL.Path)
+		defer g.cache.Invalidate(req.URL.Path)
+	} else if cached {
+		if data, ok := g.cache.get(cacheKey(req)); ok {
+			return data, statusOk
+		}
+		generation = g.cache.currentGeneration()
+	}
 	response, responseErr := g.HttpClient.Do(req)
 	if responseErr != nil {
 		panic(responseErr)
 	}
 	defer response.Body.Close()
 	body, _ := io.ReadAll(response.Body)
+	if cached && response.StatusCode == statusOk {
+		g.cache.put(cacheKey(req), req.URL.Path, body, generation)
+	}
 	if LogRawData || !LogConsoleQuiet {
 		g.logger.Infof("%s:%s  Status=%s", request.Method, req.URL, response.Status)
 		if LogFile != nil {
@@ -85,6 +105,7 @@ func (g *GeoServer) GetError(statusCode int, text []byte) (err error) {
 		geoserverErr = GsError{err: fmt.Sprintf("Unexpected Error with status code %d", statusCode)}
 	}
 	geoserverErr.dump = string(text)
+	geoserverErr.status = statusCode
 	return geoserverErr
 }
 
@@ -106,6 +127,30 @@ func IsEmpty(object interface{}) bool {
 	return false
 }
 
+// BoolPtr returns a pointer to the bool value, it's used to set optional bool fields
+func BoolPtr(value bool) *bool {
+	return &value
+}
+
+// splitQualifiedName splits a name qualified as ${workspace}:${name} to the workspace and the name,
+// workspaceName is "" if the name isn't qualified
+func splitQualifiedName(qualifiedName string) (workspaceName string, name string) {
+	if i := strings.Index(qualifiedName, ":"); i >= 0 {
+		return qualifiedName[:i], qualifiedName[i+1:]
+	}
+	return "", qualifiedName
+}
+
+// sortedKeys returns the keys of the map in the sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // SerializeToXML convert struct to xml
 func (g *GeoServer) SerializeToXML(structObj interface{}) ([]byte, error) {
 	xmlBuff := []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
@@ -165,9 +210,15 @@ func (g *GeoServer) ParseURL(urlParts ...string) (parsedURL string) {
 
 // requestResource performs request, gets resource data and fill the response struct with parsed json
 func (g *GeoServer) requestResource(targetURL string, response interface{}) (err error) {
+	return g.requestResourceAs(targetURL, jsonType, response)
+}
+
+// requestResourceAs performs request, gets resource data in the format defined by accept arg (jsonType or xmlType)
+// and fill the response struct with parsed data
+func (g *GeoServer) requestResourceAs(targetURL string, accept string, response interface{}) (err error) {
 	httpRequest := HTTPRequest{
 		Method: getMethod,
-		Accept: jsonType,
+		Accept: accept,
 		URL:    targetURL,
 		Query:  nil,
 	}
@@ -178,13 +229,23 @@ func (g *GeoServer) requestResource(targetURL string, response interface{}) (err
 		return
 	}
 
-	if err = json.Unmarshal(responseData, response); err != nil {
+	if isXMLType(accept) {
+		err = xml.Unmarshal(responseData, response)
+	} else {
+		err = json.Unmarshal(responseData, response)
+	}
+	if err != nil {
 		return fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
 	}
 
 	return
 }
 
+// isXMLType returns true if the content type is one of xml types
+func isXMLType(contentType string) bool {
+	return contentType == xmlType || contentType == appXMLType
+}
+
 // createEntity performs POST request to create a resource or entity
 // checkError is a callback function processing the error, if nil the default error processing will perform
 func (g *GeoServer) createEntity(targetURL string, entity interface{}, checkError func(statusCode int, response []byte) error) (created bool, err error) {
@@ -200,19 +261,27 @@ func (g *GeoServer) updateEntity(targetURL string, entity interface{}, checkErro
 // writeEntity performs HTTP request to write a resource or entity using POST or PUT method defined by method arg
 // checkError is a callback function processing the error, if nil the default error processing will perform
 func (g *GeoServer) writeEntity(targetURL string, method string, entity interface{}, checkError func(statusCode int, response []byte) error) (done bool, err error) {
+	return g.writeEntityAs(targetURL, method, jsonType, entity, checkError)
+}
+
+// writeEntityAs performs HTTP request to write a resource or entity serialized to the format defined by dataType arg (jsonType or xmlType)
+// checkError is a callback function processing the error, if nil the default error processing will perform
+func (g *GeoServer) writeEntityAs(targetURL string, method string, dataType string, entity interface{}, checkError func(statusCode int, response []byte) error) (done bool, err error) {
 
 	var serializedLayer []byte
-	if entity != nil {
-		serializedLayer, _ = g.SerializeStruct(entity)
-	} else {
+	if entity == nil {
 		serializedLayer = []byte{}
+	} else if isXMLType(dataType) {
+		serializedLayer, _ = g.SerializeToXML(entity)
+	} else {
+		serializedLayer, _ = g.SerializeStruct(entity)
 	}
 
 	httpRequest := HTTPRequest{
 		Method:   method,
-		Accept:   jsonType,
+		Accept:   dataType,
 		Data:     bytes.NewBuffer(serializedLayer),
-		DataType: jsonType,
+		DataType: dataType,
 		URL:      targetURL,
 		Query:    nil,
 	}
@@ -234,13 +303,233 @@ func (g *GeoServer) writeEntity(targetURL string, method string, entity interfac
 	return true, nil
 }
 
+// compactEntity converts the entity to json object tree without nulls, empty objects and arrays,
+// it's used to send only fields set in the entity during a partial update,
+// cause non-pointer structs are always serialized by encoding/json even with omitempty tag
+func compactEntity(entity interface{}) (compacted interface{}, err error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&compacted); err != nil {
+		return nil, err
+	}
+	compacted, _ = compactValue(compacted)
+	return
+}
+
+// compactValue removes nulls, empty objects and arrays from the json value, empty is true if the value itself is empty
+func compactValue(value interface{}) (compacted interface{}, empty bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case map[string]interface{}:
+		for key, item := range v {
+			if c, e := compactValue(item); e {
+				delete(v, key)
+			} else {
+				v[key] = c
+			}
+		}
+		return v, len(v) == 0
+	case []interface{}:
+		return v, len(v) == 0
+	}
+	return value, false
+}
+
+// patchEntity builds the body of a partial update request from the entity struct,
+// the fields set in the entity are sent (see compactEntity) along with the fields of the mask,
+// fields is the field mask, a list of json field names of the entity to send even if they have zero values (false, 0, ""),
+// the other fields are left untouched on the server
+func patchEntity(entity interface{}, fields []string) (patch map[string]interface{}, err error) {
+	masked, err := maskEntity(entity, fields)
+	if err != nil {
+		return nil, err
+	}
+	compacted, err := compactEntity(entity)
+	if err != nil {
+		return nil, err
+	}
+	patch, _ = compacted.(map[string]interface{})
+	if patch == nil {
+		patch = make(map[string]interface{}, len(masked))
+	}
+	for field, value := range masked {
+		patch[field] = value
+	}
+	return
+}
+
+// maskEntity builds the body of a partial update request with only the fields of the mask taken from the entity struct,
+// fields is a list of json field names of the entity
+func maskEntity(entity interface{}, fields []string) (masked map[string]interface{}, err error) {
+	value := reflect.Indirect(reflect.ValueOf(entity))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't build partial update of %v, struct is expected", value.Kind())
+	}
+	jsonFields := make(map[string]reflect.Value)
+	collectJSONFields(value, jsonFields)
+	masked = make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		fieldValue, ok := jsonFields[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s in the partial update of %v", field, value.Type().Name())
+		}
+		masked[field] = fieldValue.Interface()
+	}
+	return
+}
+
+// collectJSONFields maps the json field names of the struct value to the field values,
+// the fields of embedded structs are promoted like encoding/json does
+func collectJSONFields(value reflect.Value, jsonFields map[string]reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectJSONFields(value.Field(i), jsonFields)
+			continue
+		}
+		if name != "" && name != "-" {
+			jsonFields[name] = value.Field(i)
+		}
+	}
+}
+
+// mergeEntity merges the patch (see patchEntity) into the json object of the current entity,
+// nested objects are merged recursively, it's used for the configurations geoserver replaces as a whole
+func mergeEntity(current interface{}, patch map[string]interface{}) (merged map[string]interface{}, err error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&merged); err != nil {
+		return nil, err
+	}
+	if merged == nil {
+		merged = make(map[string]interface{}, len(patch))
+	}
+	mergeValues(merged, patch)
+	return
+}
+
+// mergeValues sets the patch values into the target object, nested objects are merged recursively
+func mergeValues(target map[string]interface{}, patch map[string]interface{}) {
+	for key, value := range patch {
+		nestedPatch, isObject := value.(map[string]interface{})
+		nestedTarget, hasObject := target[key].(map[string]interface{})
+		if isObject && hasObject {
+			mergeValues(nestedTarget, nestedPatch)
+			continue
+		}
+		target[key] = value
+	}
+}
+
+// requestResourceList performs request and returns the list of resources from the response like
+// {"collectionKey": {"itemKey": [...]}}, it handles an empty collection ("") and a single item object
+func (g *GeoServer) requestResourceList(targetURL string, query map[string]string, collectionKey string, itemKey string) (resources []*Resource, err error) {
+	httpRequest := HTTPRequest{
+		Method: getMethod,
+		Accept: jsonType,
+		URL:    targetURL,
+		Query:  query,
+	}
+	responseData, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk {
+		g.logger.Error(string(responseData))
+		err = g.GetError(responseCode, responseData)
+		return
+	}
+
+	var response map[string]json.RawMessage
+	if err = json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
+	}
+	var collection map[string]json.RawMessage
+	if err = json.Unmarshal(response[collectionKey], &collection); err != nil {
+		// geoserver returns "" for the empty collection
+		return []*Resource{}, nil
+	}
+	items := collection[itemKey]
+	resources = []*Resource{}
+	if len(items) == 0 {
+		return
+	}
+	if items[0] == '{' {
+		resource := &Resource{}
+		err = json.Unmarshal(items, resource)
+		resources = append(resources, resource)
+	} else {
+		err = json.Unmarshal(items, &resources)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
+	}
+	return
+}
+
+// requestStringList performs request and returns the list of names from the response like {"list": {"string": [...]}},
+// it handles an empty list ("") and a single name
+func (g *GeoServer) requestStringList(targetURL string, query map[string]string) (list []string, err error) {
+	httpRequest := HTTPRequest{
+		Method: getMethod,
+		Accept: jsonType,
+		URL:    targetURL,
+		Query:  query,
+	}
+	responseData, responseCode := g.DoRequest(httpRequest)
+	if responseCode != statusOk {
+		g.logger.Error(string(responseData))
+		err = g.GetError(responseCode, responseData)
+		return
+	}
+
+	var response struct {
+		List json.RawMessage `json:"list"`
+	}
+	if err = json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
+	}
+	var names struct {
+		String json.RawMessage `json:"string"`
+	}
+	list = []string{}
+	if err = json.Unmarshal(response.List, &names); err != nil || len(names.String) == 0 {
+		// geoserver returns "" for the empty list
+		return list, nil
+	}
+	if names.String[0] == '"' {
+		var name string
+		err = json.Unmarshal(names.String, &name)
+		list = append(list, name)
+	} else {
+		err = json.Unmarshal(names.String, &list)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't parse respose from %v: %v", targetURL, err)
+	}
+	return
+}
+
 // deleteEntity performs DELETE request to delete the entity
 func (g *GeoServer) deleteEntity(targetURL string) (deleted bool, err error) {
+	return g.deleteEntityWithQuery(targetURL, nil)
+}
+
+// deleteEntityWithQuery performs DELETE request with query parameters (like recurse or purge) to delete the entity
+func (g *GeoServer) deleteEntityWithQuery(targetURL string, query map[string]string) (deleted bool, err error) {
 
 	httpRequest := HTTPRequest{
 		Method: deleteMethod,
 		Accept: jsonType,
 		URL:    targetURL,
+		Query:  query,
 	}
 	response, responseCode := g.DoRequest(httpRequest)
 	if responseCode != statusOk {
@@ -1,6 +1,9 @@
 package geoserver
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -90,3 +93,66 @@ func BenchmarkIsEmpty(b *testing.B) {
 		IsEmpty(struct{}{})
 	}
 }
+func TestPatchEntity(t *testing.T) {
+	patch, err := patchEntity(Layer{Queryable: false, Opaque: true, Path: "/"}, []string{"queryable", "path"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"queryable": false, "opaque": true, "path": "/"}, patch)
+	patch, err = patchEntity(Layer{}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, patch)
+	patch, err = patchEntity(&Datastore{Enabled: false, Name: "store"}, nil)
+	assert.Nil(t, err)
+	data, _ := json.Marshal(patch)
+	assert.Equal(t, `{"name":"store"}`, string(data))
+	_, err = patchEntity(Workspace{}, []string{"dummy"})
+	assert.NotNil(t, err)
+	_, err = patchEntity("dummy", []string{"name"})
+	assert.NotNil(t, err)
+}
+func TestRequestLists(t *testing.T) {
+	responses := map[string]string{
+		"/rest/array":        `{"wmsStores":{"wmsStore":[{"name":"a","href":"http://localhost/a.json"},{"name":"b"}]}}`,
+		"/rest/single":       `{"wmsStores":{"wmsStore":{"name":"a"}}}`,
+		"/rest/empty":        `{"wmsStores":""}`,
+		"/rest/names":        `{"list":{"string":["a","b"]}}`,
+		"/rest/single_name":  `{"list":{"string":"a"}}`,
+		"/rest/empty_names":  `{"list":""}`,
+		"/rest/broken_names": `<list/>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	resources, err := gsCatalog.requestResourceList(gsCatalog.ParseURL("rest", "array"), nil, "wmsStores", "wmsStore")
+	assert.Nil(t, err)
+	assert.Equal(t, []*Resource{{Name: "a", Href: "http://localhost/a.json"}, {Name: "b"}}, resources)
+	resources, err = gsCatalog.requestResourceList(gsCatalog.ParseURL("rest", "single"), nil, "wmsStores", "wmsStore")
+	assert.Nil(t, err)
+	assert.Equal(t, []*Resource{{Name: "a"}}, resources)
+	resources, err = gsCatalog.requestResourceList(gsCatalog.ParseURL("rest", "empty"), nil, "wmsStores", "wmsStore")
+	assert.Nil(t, err)
+	assert.Empty(t, resources)
+	resources, err = gsCatalog.requestResourceList(gsCatalog.ParseURL("rest", "dummy"), nil, "wmsStores", "wmsStore")
+	assert.Nil(t, resources)
+	assert.NotNil(t, err)
+
+	names, err := gsCatalog.requestStringList(gsCatalog.ParseURL("rest", "names"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+	names, err = gsCatalog.requestStringList(gsCatalog.ParseURL("rest", "single_name"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, names)
+	names, err = gsCatalog.requestStringList(gsCatalog.ParseURL("rest", "empty_names"), nil)
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+	names, err = gsCatalog.requestStringList(gsCatalog.ParseURL("rest", "broken_names"), nil)
+	assert.Nil(t, names)
+	assert.NotNil(t, err)
+}
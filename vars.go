@@ -3,6 +3,8 @@ package geoserver
 const (
 	statusOk            = 200
 	statusCreated       = 201
+	statusAccepted      = 202
+	statusNoContent     = 204
 	statusNotAllowed    = 405
 	statusForbidden     = 403
 	statusInternalError = 500
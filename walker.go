@@ -0,0 +1,384 @@
+package geoserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	KindWMSStore  = "wmsStore"
+	KindWMTSStore = "wmtsStore"
+	KindWMSLayer  = "wmsLayer"
+	KindWMTSLayer = "wmtsLayer"
+)
+
+// ErrWalkSkip is returned by the visitor callback to skip the children of the visited workspace or store
+var ErrWalkSkip = errors.New("skip this node")
+
+// storeKind describes the store listing, path is the href path element of the stores,
+// collection and item are the json keys of the stores and the resources listings
+type storeKind struct {
+	path, collection, item, kind                              string
+	resources, resourceCollection, resourceItem, resourceKind string
+}
+
+var storeKinds = []storeKind{
+	{"datastores", "dataStores", "dataStore", KindDatastore, "featuretypes", "featureTypes", "featureType", KindFeatureType},
+	{"coveragestores", "coverageStores", "coverageStore", KindCoverageStore, "coverages", "coverages", "coverage", KindCoverage},
+	{"wmsstores", "wmsStores", "wmsStore", KindWMSStore, "wmslayers", "wmsLayers", "wmsLayer", KindWMSLayer},
+	{"wmtsstores", "wmtsStores", "wmtsStore", KindWMTSStore, "wmtslayers", "wmtsLayers", "wmtsLayer", KindWMTSLayer},
+}
+
+// CatalogGraph is the catalog resource tree resolved by WalkCatalog,
+// LayerGroups and Styles are the global (not workspace) layergroups and styles
+type CatalogGraph struct {
+	Workspaces  []*WorkspaceNode
+	LayerGroups []*LayerGroupNode
+	Styles      []string
+}
+
+// WorkspaceNode is the workspace with its stores, layers, layergroups and style names
+type WorkspaceNode struct {
+	Name        string
+	Stores      []*StoreNode
+	Layers      []*LayerNode
+	LayerGroups []*LayerGroupNode
+	Styles      []string
+}
+
+// StoreNode is the store of the workspace, Kind is one of KindDatastore, KindCoverageStore, KindWMSStore, KindWMTSStore
+type StoreNode struct {
+	Workspace *WorkspaceNode
+	Kind      string
+	Name      string
+	Href      string
+	Resources []*ResourceNode
+}
+
+// ResourceNode is the configured resource of the store (feature type, coverage, cascaded layer),
+// Kind is one of KindFeatureType, KindCoverage, KindWMSLayer, KindWMTSLayer, Layer is nil for not published resources
+type ResourceNode struct {
+	Store *StoreNode
+	Kind  string
+	Name  string
+	Href  string
+	Layer *LayerNode
+}
+
+// LayerNode is the published layer, Resource is nil if the layer resource isn't found in the workspace stores,
+// DefaultStyle and Styles are the qualified style names
+type LayerNode struct {
+	Workspace    *WorkspaceNode
+	Name         string
+	Resource     *ResourceNode
+	DefaultStyle string
+	Styles       []string
+	Layer        *Layer
+}
+
+// LayerGroupNode is the layergroup, Workspace is nil for the global layergroups
+type LayerGroupNode struct {
+	Workspace  *WorkspaceNode
+	Name       string
+	LayerGroup *LayerGroup
+}
+
+// CatalogVisitor is called for the nodes during the walk, nil callbacks are ignored,
+// the callbacks are never called concurrently, an error returned by the callback stops the walk
+// except ErrWalkSkip returned for the workspace or the store which skips its children
+type CatalogVisitor struct {
+	Workspace  func(node *WorkspaceNode) error
+	Store      func(node *StoreNode) error
+	Resource   func(node *ResourceNode) error
+	Layer      func(node *LayerNode) error
+	LayerGroup func(node *LayerGroupNode) error
+}
+
+// WalkOptions configures the walk, Workspaces limits the walked workspaces (all if empty, the global
+// layergroups and styles are walked only in this case), Workers is the number of concurrent requests
+// resolving the layers and layergroups (1 if not set)
+type WalkOptions struct {
+	Workspaces []string
+	Workers    int
+	Visitor    CatalogVisitor
+}
+
+// walker is the state of the walk
+type walker struct {
+	g       *GeoServer
+	options WalkOptions
+	visit   sync.Mutex
+}
+
+// WalkCatalog traverses workspaces, stores, resources, layers with styles and layergroups
+// and returns the resolved graph
+func (g *GeoServer) WalkCatalog(options WalkOptions) (graph *CatalogGraph, err error) {
+	w := &walker{g: g, options: options}
+	graph = &CatalogGraph{}
+	workspaces := options.Workspaces
+	if len(workspaces) == 0 {
+		resources, err := g.GetWorkspaces()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			workspaces = append(workspaces, r.Name)
+		}
+	}
+	for _, name := range workspaces {
+		node, err := w.walkWorkspace(name)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			graph.Workspaces = append(graph.Workspaces, node)
+		}
+	}
+	if len(options.Workspaces) != 0 {
+		return graph, nil
+	}
+	if graph.Styles, err = w.styleNames(""); err != nil {
+		return nil, err
+	}
+	if graph.LayerGroups, err = w.walkLayerGroups(nil); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// call runs the visitor callback serialized with the other callbacks
+func (w *walker) call(callback func() error) error {
+	w.visit.Lock()
+	defer w.visit.Unlock()
+	return callback()
+}
+
+// walkWorkspace returns nil node if the workspace is skipped
+func (w *walker) walkWorkspace(name string) (*WorkspaceNode, error) {
+	node := &WorkspaceNode{Name: name}
+	if visit := w.options.Visitor.Workspace; visit != nil {
+		if err := w.call(func() error { return visit(node) }); err == ErrWalkSkip {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if node.Styles, err = w.styleNames(name); err != nil {
+		return nil, err
+	}
+	if err = w.walkStores(node); err != nil {
+		return nil, err
+	}
+	if err = w.walkLayers(node); err != nil {
+		return nil, err
+	}
+	if node.LayerGroups, err = w.walkLayerGroups(node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (w *walker) styleNames(workspaceName string) ([]string, error) {
+	styles, err := w.g.GetStyles(workspaceName)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(styles))
+	for _, s := range styles {
+		_, name := splitQualifiedName(s.Name)
+		names = append(names, qualifiedStyleName(workspaceName, name))
+	}
+	return names, nil
+}
+
+func (w *walker) walkStores(ws *WorkspaceNode) error {
+	for _, kinds := range storeKinds {
+		stores, err := w.g.requestResourceList(w.g.ParseURL("rest", "workspaces", ws.Name, kinds.path), nil, kinds.collection, kinds.item)
+		if err != nil {
+			return err
+		}
+		for _, s := range stores {
+			store := &StoreNode{Workspace: ws, Kind: kinds.kind, Name: s.Name, Href: s.Href}
+			if visit := w.options.Visitor.Store; visit != nil {
+				if err = w.call(func() error { return visit(store) }); err == ErrWalkSkip {
+					ws.Stores = append(ws.Stores, store)
+					continue
+				} else if err != nil {
+					return err
+				}
+			}
+			resourcesURL := w.g.ParseURL("rest", "workspaces", ws.Name, kinds.path, s.Name, kinds.resources)
+			resources, err := w.g.requestResourceList(resourcesURL, nil, kinds.resourceCollection, kinds.resourceItem)
+			if err != nil {
+				return err
+			}
+			for _, r := range resources {
+				resource := &ResourceNode{Store: store, Kind: kinds.resourceKind, Name: r.Name, Href: r.Href}
+				if visit := w.options.Visitor.Resource; visit != nil {
+					if err = w.call(func() error { return visit(resource) }); err != nil {
+						return err
+					}
+				}
+				store.Resources = append(store.Resources, resource)
+			}
+			ws.Stores = append(ws.Stores, store)
+		}
+	}
+	return nil
+}
+
+func (w *walker) workers() int {
+	if w.options.Workers < 1 {
+		return 1
+	}
+	return w.options.Workers
+}
+
+func (w *walker) walkLayers(ws *WorkspaceNode) error {
+	layers, err := w.g.GetLayers(ws.Name)
+	if err != nil {
+		return err
+	}
+	nodes := make([]*LayerNode, len(layers))
+	operations := make([]BulkOperation, 0, len(layers))
+	for i, l := range layers {
+		i := i
+		_, name := splitQualifiedName(l.Name)
+		operations = append(operations, BulkOperation{Name: name, Run: func() error {
+			layer, err := w.g.GetLayer(ws.Name, name)
+			if err != nil {
+				return err
+			}
+			node := &LayerNode{Workspace: ws, Name: name, Layer: layer}
+			if layer.DefaultStyle != nil {
+				node.DefaultStyle = layer.DefaultStyle.Name
+			}
+			if layer.Styles != nil {
+				for _, s := range layer.Styles.Style {
+					node.Styles = append(node.Styles, s.Name)
+				}
+			}
+			nodes[i] = node
+			return nil
+		}})
+	}
+	if _, err = RunBulk(operations, BulkOptions{Workers: w.workers(), StopOnError: true}); err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		node.Resource = ws.findResource(node.Layer.Resource.Href)
+		if node.Resource != nil {
+			node.Resource.Layer = node
+		}
+		if visit := w.options.Visitor.Layer; visit != nil {
+			if err = w.call(func() error { return visit(node) }); err != nil {
+				return err
+			}
+		}
+		ws.Layers = append(ws.Layers, node)
+	}
+	return nil
+}
+
+// findResource finds the resource node referenced by the layer resource href,
+// e.g. .../workspaces/ws/datastores/ds/featuretypes/roads.json
+func (ws *WorkspaceNode) findResource(href string) *ResourceNode {
+	parts := strings.Split(href, "/")
+	for i := 0; i+3 < len(parts); i++ {
+		for _, kinds := range storeKinds {
+			if parts[i] != kinds.path || parts[i+2] != kinds.resources {
+				continue
+			}
+			if resource := ws.findStoreResource(kinds.kind, parts[i+1], strings.TrimSuffix(parts[i+3], ".json")); resource != nil {
+				return resource
+			}
+		}
+	}
+	return nil
+}
+
+func (ws *WorkspaceNode) findStoreResource(kind string, storeName string, resourceName string) *ResourceNode {
+	for _, store := range ws.Stores {
+		if store.Kind != kind || store.Name != storeName {
+			continue
+		}
+		for _, resource := range store.Resources {
+			if resource.Name == resourceName {
+				return resource
+			}
+		}
+	}
+	return nil
+}
+
+// walkLayerGroups resolves the layergroups of the workspace, the global ones if ws is nil
+func (w *walker) walkLayerGroups(ws *WorkspaceNode) ([]*LayerGroupNode, error) {
+	workspaceName := ""
+	if ws != nil {
+		workspaceName = ws.Name
+	}
+	groups, err := w.g.GetLayerGroups(workspaceName)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*LayerGroupNode, len(groups))
+	operations := make([]BulkOperation, 0, len(groups))
+	for i, lg := range groups {
+		i := i
+		_, name := splitQualifiedName(lg.Name)
+		operations = append(operations, BulkOperation{Name: name, Run: func() error {
+			layerGroup, err := w.g.GetLayerGroup(workspaceName, name)
+			if err != nil {
+				return err
+			}
+			nodes[i] = &LayerGroupNode{Workspace: ws, Name: name, LayerGroup: layerGroup}
+			return nil
+		}})
+	}
+	if _, err = RunBulk(operations, BulkOptions{Workers: w.workers(), StopOnError: true}); err != nil {
+		return nil, err
+	}
+	if visit := w.options.Visitor.LayerGroup; visit != nil {
+		for _, node := range nodes {
+			node := node
+			if err = w.call(func() error { return visit(node) }); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// Layers returns all layers of the graph
+func (graph *CatalogGraph) Layers() (layers []*LayerNode) {
+	for _, ws := range graph.Workspaces {
+		layers = append(layers, ws.Layers...)
+	}
+	return
+}
+
+// ResolveHref requests the resource referenced by the href of the catalog listing (e.g. Resource.Href)
+// and fills v with the wrapped entity, e.g. FeatureType for {"featureType":{...}},
+// the href is resolved relative to ServerURL so the proxy base url of geoserver doesn't matter
+func (g *GeoServer) ResolveHref(href string, v interface{}) error {
+	targetURL := href
+	if i := strings.Index(href, "/rest/"); i >= 0 {
+		targetURL = g.ServerURL + href[i+1:]
+	}
+	var wrapper map[string]json.RawMessage
+	if err := g.requestResource(targetURL, &wrapper); err != nil {
+		return err
+	}
+	if len(wrapper) != 1 {
+		return fmt.Errorf("unexpected response of %s", href)
+	}
+	for _, entity := range wrapper {
+		return json.Unmarshal(entity, v)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkCatalog(t *testing.T) {
+	var layerRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces":
+			w.Write([]byte(`{"workspaces":{"workspace":[{"name":"ws"}]}}`))
+		case "/rest/workspaces/ws/styles":
+			w.Write([]byte(`{"styles":{"style":[{"name":"roads_style"}]}}`))
+		case "/rest/styles":
+			w.Write([]byte(`{"styles":{"style":[{"name":"line"}]}}`))
+		case "/rest/workspaces/ws/datastores":
+			w.Write([]byte(`{"dataStores":{"dataStore":[{"name":"pg"}]}}`))
+		case "/rest/workspaces/ws/datastores/pg/featuretypes":
+			w.Write([]byte(`{"featureTypes":{"featureType":[{"name":"roads"},{"name":"rivers"}]}}`))
+		case "/rest/workspaces/ws/coveragestores":
+			w.Write([]byte(`{"coverageStores":{"coverageStore":[{"name":"dem"}]}}`))
+		case "/rest/workspaces/ws/coveragestores/dem/coverages":
+			w.Write([]byte(`{"coverages":{"coverage":[{"name":"dem"}]}}`))
+		case "/rest/workspaces/ws/wmsstores", "/rest/workspaces/ws/wmtsstores":
+			w.Write([]byte(`{"wmsStores":""}`))
+		case "/rest/workspaces/ws/layers":
+			w.Write([]byte(`{"layers":{"layer":[{"name":"ws:roads"},{"name":"ws:dem"}]}}`))
+		case "/rest/workspaces/ws/layers/roads":
+			atomic.AddInt32(&layerRequests, 1)
+			w.Write([]byte(`{"layer":{"name":"roads","defaultStyle":{"name":"ws:roads_style"},"styles":{"style":[{"name":"line"}]},
+				"resource":{"name":"ws:roads","href":"http://proxy/geoserver/rest/workspaces/ws/datastores/pg/featuretypes/roads.json"}}}`))
+		case "/rest/workspaces/ws/layers/dem":
+			atomic.AddInt32(&layerRequests, 1)
+			w.Write([]byte(`{"layer":{"name":"dem","resource":{"name":"ws:dem","href":"http://proxy/geoserver/rest/workspaces/ws/coveragestores/dem/coverages/dem.json"}}}`))
+		case "/rest/workspaces/ws/layergroups":
+			w.Write([]byte(`{"layerGroups":{"layerGroup":[{"name":"base"}]}}`))
+		case "/rest/workspaces/ws/layergroups/base":
+			w.Write([]byte(`{"layerGroup":{"name":"base","mode":"SINGLE"}}`))
+		case "/rest/layergroups":
+			w.Write([]byte(`{"layerGroups":""}`))
+		case "/rest/workspaces/ws/datastores/pg/featuretypes/roads.json":
+			w.Write([]byte(`{"featureType":{"name":"roads","title":"Roads"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	catalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	var visited []string
+	graph, err := catalog.WalkCatalog(WalkOptions{Workers: 2, Visitor: CatalogVisitor{
+		Store: func(node *StoreNode) error {
+			visited = append(visited, node.Kind+":"+node.Name)
+			return nil
+		},
+		Layer: func(node *LayerNode) error {
+			visited = append(visited, "layer:"+node.Name)
+			return nil
+		},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&layerRequests))
+	assert.Equal(t, []string{"datastore:pg", "coverageStore:dem", "layer:roads", "layer:dem"}, visited)
+	assert.Equal(t, []string{"line"}, graph.Styles)
+	assert.Empty(t, graph.LayerGroups)
+
+	ws := graph.Workspaces[0]
+	assert.Equal(t, []string{"ws:roads_style"}, ws.Styles)
+	assert.Len(t, ws.Stores, 2)
+	assert.Len(t, ws.Stores[0].Resources, 2)
+	assert.Nil(t, ws.Stores[0].Resources[1].Layer)
+	assert.Len(t, ws.LayerGroups, 1)
+	assert.Equal(t, "SINGLE", ws.LayerGroups[0].LayerGroup.Mode)
+
+	layers := graph.Layers()
+	assert.Len(t, layers, 2)
+	roads := layers[0]
+	assert.Equal(t, "ws:roads_style", roads.DefaultStyle)
+	assert.Equal(t, []string{"line"}, roads.Styles)
+	assert.Equal(t, KindFeatureType, roads.Resource.Kind)
+	assert.Equal(t, "pg", roads.Resource.Store.Name)
+	assert.Equal(t, "ws", roads.Resource.Store.Workspace.Name)
+	assert.Equal(t, roads, roads.Resource.Layer)
+	assert.Equal(t, KindCoverage, layers[1].Resource.Kind)
+
+	var featureType FeatureType
+	assert.Nil(t, catalog.ResolveHref(roads.Layer.Resource.Href, &featureType))
+	assert.Equal(t, "Roads", featureType.Title)
+
+	graph, err = catalog.WalkCatalog(WalkOptions{Workspaces: []string{"ws"}, Visitor: CatalogVisitor{
+		Workspace: func(node *WorkspaceNode) error { return ErrWalkSkip },
+	}})
+	assert.Nil(t, err)
+	assert.Empty(t, graph.Workspaces)
+}
@@ -0,0 +1,106 @@
+package geoserver
+
+import (
+	"strconv"
+)
+
+// WMSLayerService define all geoserver cascaded WMS layers operations
+type WMSLayerService interface {
+	GetWMSLayers(workspaceName string, wmsStoreName string) (wmsLayers []*Resource, err error)
+	GetAvailableWMSLayers(workspaceName string, wmsStoreName string) (layerNames []string, err error)
+	GetWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string) (wmsLayer *WMSLayer, err error)
+	PublishWMSLayer(workspaceName string, wmsStoreName string, wmsLayer WMSLayer) (published bool, err error)
+	UpdateWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string, wmsLayer WMSLayer, fields ...string) (modified bool, err error)
+	DeleteWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string, recurse bool) (deleted bool, err error)
+}
+
+// WMSLayer geoserver cascaded WMS layer,
+// NativeName is the layer name on the remote WMS server, Name is the name it's presented at geoserver
+type WMSLayer struct {
+	Name                  string             `json:"name,omitempty"`
+	NativeName            string             `json:"nativeName,omitempty"`
+	Namespace             *Resource          `json:"namespace,omitempty"`
+	Title                 string             `json:"title,omitempty"`
+	Abstract              string             `json:"abstract,omitempty"`
+	Keywords              *Keywords          `json:"keywords,omitempty"`
+	NativeCRS             *CRSType           `json:"nativeCRS,omitempty"`
+	Srs                   string             `json:"srs,omitempty"`
+	NativeBoundingBox     *NativeBoundingBox `json:"nativeBoundingBox,omitempty"`
+	LatLonBoundingBox     *LatLonBoundingBox `json:"latLonBoundingBox,omitempty"`
+	ProjectionPolicy      string             `json:"projectionPolicy,omitempty"`
+	Enabled               bool               `json:"enabled,omitempty"`
+	Store                 *Resource          `json:"store,omitempty"`
+	ForcedRemoteStyle     string             `json:"forcedRemoteStyle,omitempty"`
+	PreferredFormat       string             `json:"preferredFormat,omitempty"`
+	MetadataBBoxRespected bool               `json:"metadataBBoxRespected,omitempty"`
+	MinScale              float64            `json:"minScale,omitempty"`
+	MaxScale              float64            `json:"maxScale,omitempty"`
+}
+
+// WMSLayerRequestBody geoserver cascaded WMS layer to send to api
+type WMSLayerRequestBody struct {
+	WMSLayer *WMSLayer `json:"wmsLayer,omitempty"`
+}
+
+// GetWMSLayers returns the published cascaded layers of the WMS store as resources,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMSLayers(workspaceName string, wmsStoreName string) (wmsLayers []*Resource, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers")
+	return g.requestResourceList(targetURL, nil, "wmsLayers", "wmsLayer")
+}
+
+// GetAvailableWMSLayers returns the names of the remote WMS server layers which are available for publishing,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetAvailableWMSLayers(workspaceName string, wmsStoreName string) (layerNames []string, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers")
+	return g.requestStringList(targetURL, map[string]string{"list": "available"})
+}
+
+// GetWMSLayer returns the cascaded WMS layer,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string) (wmsLayer *WMSLayer, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers", wmsLayerName)
+	var wmsLayerResponse WMSLayerRequestBody
+	if err = g.requestResource(targetURL, &wmsLayerResponse); err != nil {
+		return nil, err
+	}
+	return wmsLayerResponse.WMSLayer, nil
+}
+
+// PublishWMSLayer publishes the remote WMS layer wmsLayer.NativeName with the name wmsLayer.Name,
+// if wmsLayer.NativeName is empty wmsLayer.Name is used,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) PublishWMSLayer(workspaceName string, wmsStoreName string, wmsLayer WMSLayer) (published bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers")
+	if wmsLayer.NativeName == "" {
+		wmsLayer.NativeName = wmsLayer.Name
+	}
+	return g.createEntity(targetURL, WMSLayerRequestBody{WMSLayer: &wmsLayer}, nil)
+}
+
+// UpdateWMSLayer partial update the cascaded WMS layer else return error,
+// fields is an optional field mask, a list of WMS layer json field names to send even if they are false, 0 or "" (e.g. "enabled"),
+// without fields only the fields set in the wmsLayer are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string, wmsLayer WMSLayer, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers", wmsLayerName)
+	patch, err := patchEntity(wmsLayer, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"wmsLayer": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
+// DeleteWMSLayer deletes the cascaded WMS layer, recurse removes the published layer as well,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteWMSLayer(workspaceName string, wmsStoreName string, wmsLayerName string, recurse bool) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName, "wmslayers", wmsLayerName)
+	return g.deleteEntityWithQuery(targetURL, map[string]string{"recurse": strconv.FormatBool(recurse)})
+}
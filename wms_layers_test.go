@@ -0,0 +1,50 @@
+package geoserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWMSLayers(t *testing.T) {
+	test_before(t)
+	wmsStoreTestPrecondition(t)
+	defer wmsStoreTestPostcondition()
+
+	available, err := gsCatalog.GetAvailableWMSLayers(testWorkspace, wmsTestStoreName)
+	assert.Nil(t, err)
+	assert.Contains(t, available, "topp:states")
+
+	published, err := gsCatalog.PublishWMSLayer(testWorkspace, wmsTestStoreName, WMSLayer{Name: "cascaded_states", NativeName: "topp:states", Enabled: true})
+	assert.True(t, published)
+	assert.Nil(t, err)
+	layers, err := gsCatalog.GetWMSLayers(testWorkspace, wmsTestStoreName)
+	assert.Nil(t, err)
+	assert.Len(t, layers, 1)
+	layer, err := gsCatalog.GetWMSLayer(testWorkspace, wmsTestStoreName, "cascaded_states")
+	assert.Nil(t, err)
+	assert.Equal(t, "topp:states", layer.NativeName)
+
+	modified, err := gsCatalog.UpdateWMSLayer(testWorkspace, wmsTestStoreName, "cascaded_states", WMSLayer{Title: "Cascaded states", PreferredFormat: "image/png"})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+
+	published, err = gsCatalog.PublishWMSLayer(testWorkspace, "dummy_store", WMSLayer{Name: "topp:states"})
+	assert.False(t, published)
+	assert.NotNil(t, err)
+
+	deleted, err := gsCatalog.DeleteWMSLayer(testWorkspace, wmsTestStoreName, "cascaded_states", true)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	layer, err = gsCatalog.GetWMSLayer(testWorkspace, wmsTestStoreName, "cascaded_states")
+	assert.Nil(t, layer)
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementWMSLayerService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	WMSLayerServiceType := reflect.TypeOf((*WMSLayerService)(nil)).Elem()
+	check := gsCatalog.Implements(WMSLayerServiceType)
+	assert.True(t, check)
+}
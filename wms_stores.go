@@ -0,0 +1,94 @@
+package geoserver
+
+import (
+	"strconv"
+)
+
+// WMSStoreService define all geoserver WMS stores (cascaded WMS servers) operations
+type WMSStoreService interface {
+	GetWMSStores(workspaceName string) (wmsStores []*Resource, err error)
+	GetWMSStore(workspaceName string, wmsStoreName string) (wmsStore *WMSStore, err error)
+	CreateWMSStore(workspaceName string, wmsStore WMSStore) (created bool, err error)
+	UpdateWMSStore(workspaceName string, wmsStoreName string, wmsStore WMSStore, fields ...string) (modified bool, err error)
+	DeleteWMSStore(workspaceName string, wmsStoreName string, recurse bool) (deleted bool, err error)
+}
+
+// WMSStore geoserver WMS store, the remote WMS server cascaded by geoserver,
+// ReadTimeout and ConnectTimeout are in seconds
+type WMSStore struct {
+	Name                 string    `json:"name,omitempty"`
+	Description          string    `json:"description,omitempty"`
+	Type                 string    `json:"type,omitempty"`
+	Enabled              bool      `json:"enabled,omitempty"`
+	Workspace            *Resource `json:"workspace,omitempty"`
+	Default              bool      `json:"_default,omitempty"`
+	CapabilitiesURL      string    `json:"capabilitiesURL,omitempty"`
+	User                 string    `json:"user,omitempty"`
+	Password             string    `json:"password,omitempty"`
+	MaxConnections       int       `json:"maxConnections,omitempty"`
+	ReadTimeout          int       `json:"readTimeout,omitempty"`
+	ConnectTimeout       int       `json:"connectTimeout,omitempty"`
+	UseConnectionPooling *bool     `json:"useConnectionPooling,omitempty"`
+	Metadata             *Metadata `json:"metadata,omitempty"`
+	WMSLayers            string    `json:"wmsLayers,omitempty"`
+}
+
+// WMSStoreRequestBody geoserver WMS store to send to api
+type WMSStoreRequestBody struct {
+	WMSStore *WMSStore `json:"wmsStore,omitempty"`
+}
+
+// GetWMSStores return all WMS stores in the workspace as resources,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMSStores(workspaceName string) (wmsStores []*Resource, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores")
+	return g.requestResourceList(targetURL, nil, "wmsStores", "wmsStore")
+}
+
+// GetWMSStore return WMS store from the workspace,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMSStore(workspaceName string, wmsStoreName string) (wmsStore *WMSStore, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName)
+	var wmsStoreResponse WMSStoreRequestBody
+	if err = g.requestResource(targetURL, &wmsStoreResponse); err != nil {
+		return nil, err
+	}
+	return wmsStoreResponse.WMSStore, nil
+}
+
+// CreateWMSStore creates WMS store in the workspace, if wmsStore.Type is empty "WMS" is used,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) CreateWMSStore(workspaceName string, wmsStore WMSStore) (created bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores")
+	if wmsStore.Type == "" {
+		wmsStore.Type = "WMS"
+	}
+	return g.createEntity(targetURL, WMSStoreRequestBody{WMSStore: &wmsStore}, nil)
+}
+
+// UpdateWMSStore partial update WMS store in geoserver else return error,
+// fields is an optional field mask, a list of WMS store json field names to send even if they are false, 0 or "" (e.g. "enabled"),
+// without fields only the fields set in the wmsStore are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMSStore(workspaceName string, wmsStoreName string, wmsStore WMSStore, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName)
+	patch, err := patchEntity(wmsStore, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"wmsStore": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
+// DeleteWMSStore deletes WMS store from geoserver, recurse removes the cascaded layers as well,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteWMSStore(workspaceName string, wmsStoreName string, recurse bool) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmsstores", wmsStoreName)
+	return g.deleteEntityWithQuery(targetURL, map[string]string{"recurse": strconv.FormatBool(recurse)})
+}
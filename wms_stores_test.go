@@ -0,0 +1,79 @@
+package geoserver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	wmsTestStoreName       = "cascaded_wms_test"
+	wmsTestCapabilitiesURL = "http://localhost:8080/geoserver/wms?SERVICE=WMS&REQUEST=GetCapabilities"
+)
+
+func wmsStoreTestPrecondition(t *testing.T) {
+	_, err := gsCatalog.CreateWorkspace(testWorkspace)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		assert.Fail(t, "can't create workspace as a precondition for WMS store test")
+	}
+	_, err = gsCatalog.CreateWMSStore(testWorkspace, WMSStore{
+		Name:            wmsTestStoreName,
+		CapabilitiesURL: wmsTestCapabilitiesURL,
+		Enabled:         true,
+		MaxConnections:  6,
+		ReadTimeout:     60,
+		ConnectTimeout:  30,
+	})
+	if err != nil && !strings.Contains(err.Error(), "exists") {
+		assert.Fail(t, "can't create WMS store as a precondition for WMS store test", err.Error())
+	}
+}
+
+func wmsStoreTestPostcondition() {
+	_, _ = gsCatalog.DeleteWorkspace(testWorkspace, true)
+}
+
+func TestWMSStores(t *testing.T) {
+	test_before(t)
+	wmsStoreTestPrecondition(t)
+	defer wmsStoreTestPostcondition()
+
+	stores, err := gsCatalog.GetWMSStores(testWorkspace)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, stores)
+	store, err := gsCatalog.GetWMSStore(testWorkspace, wmsTestStoreName)
+	assert.Nil(t, err)
+	assert.Equal(t, wmsTestCapabilitiesURL, store.CapabilitiesURL)
+	assert.Equal(t, 6, store.MaxConnections)
+
+	modified, err := gsCatalog.UpdateWMSStore(testWorkspace, wmsTestStoreName, WMSStore{Enabled: false, ReadTimeout: 10}, "enabled", "readTimeout")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	store, err = gsCatalog.GetWMSStore(testWorkspace, wmsTestStoreName)
+	assert.Nil(t, err)
+	assert.False(t, store.Enabled)
+	assert.Equal(t, 10, store.ReadTimeout)
+
+	created, err := gsCatalog.CreateWMSStore(testWorkspace, WMSStore{Name: wmsTestStoreName, CapabilitiesURL: wmsTestCapabilitiesURL})
+	assert.False(t, created)
+	assert.NotNil(t, err)
+	store, err = gsCatalog.GetWMSStore(testWorkspace, "dummy_store")
+	assert.Nil(t, store)
+	assert.NotNil(t, err)
+
+	deleted, err := gsCatalog.DeleteWMSStore(testWorkspace, wmsTestStoreName, true)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	deleted, err = gsCatalog.DeleteWMSStore(testWorkspace, wmsTestStoreName, true)
+	assert.False(t, deleted)
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementWMSStoreService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	WMSStoreServiceType := reflect.TypeOf((*WMSStoreService)(nil)).Elem()
+	check := gsCatalog.Implements(WMSStoreServiceType)
+	assert.True(t, check)
+}
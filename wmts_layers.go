@@ -0,0 +1,150 @@
+package geoserver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WMTSLayerService define all geoserver cascaded WMTS layers operations
+type WMTSLayerService interface {
+	GetWMTSLayers(workspaceName string, wmtsStoreName string) (wmtsLayers []*Resource, err error)
+	GetAvailableWMTSLayers(workspaceName string, wmtsStoreName string) (layerNames []string, err error)
+	GetWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string) (wmtsLayer *WMTSLayer, err error)
+	PublishWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayer WMTSLayer) (published bool, err error)
+	UpdateWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string, wmtsLayer WMTSLayer, fields ...string) (modified bool, err error)
+	DeleteWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string, recurse bool) (deleted bool, err error)
+	SetWMTSLayerTileMatrixSets(workspaceName string, wmtsLayerName string, tileMatrixSets []string) (err error)
+}
+
+// WMTSLayer geoserver cascaded WMTS layer,
+// NativeName is the layer identifier on the remote WMTS server, Name is the name it's presented at geoserver
+type WMTSLayer struct {
+	Name              string             `json:"name,omitempty"`
+	NativeName        string             `json:"nativeName,omitempty"`
+	Namespace         *Resource          `json:"namespace,omitempty"`
+	Title             string             `json:"title,omitempty"`
+	Abstract          string             `json:"abstract,omitempty"`
+	Keywords          *Keywords          `json:"keywords,omitempty"`
+	NativeCRS         *CRSType           `json:"nativeCRS,omitempty"`
+	Srs               string             `json:"srs,omitempty"`
+	NativeBoundingBox *NativeBoundingBox `json:"nativeBoundingBox,omitempty"`
+	LatLonBoundingBox *LatLonBoundingBox `json:"latLonBoundingBox,omitempty"`
+	ProjectionPolicy  string             `json:"projectionPolicy,omitempty"`
+	Enabled           bool               `json:"enabled,omitempty"`
+	Store             *Resource          `json:"store,omitempty"`
+}
+
+// WMTSLayerRequestBody geoserver cascaded WMTS layer to send to api
+type WMTSLayerRequestBody struct {
+	WMTSLayer *WMTSLayer `json:"wmtsLayer,omitempty"`
+}
+
+// GetWMTSLayers returns the published cascaded layers of the WMTS store as resources,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMTSLayers(workspaceName string, wmtsStoreName string) (wmtsLayers []*Resource, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers")
+	return g.requestResourceList(targetURL, nil, "wmtsLayers", "wmtsLayer")
+}
+
+// GetAvailableWMTSLayers returns the identifiers of the remote WMTS server layers which are available for publishing,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetAvailableWMTSLayers(workspaceName string, wmtsStoreName string) (layerNames []string, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers")
+	return g.requestStringList(targetURL, map[string]string{"list": "available"})
+}
+
+// GetWMTSLayer returns the cascaded WMTS layer,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string) (wmtsLayer *WMTSLayer, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers", wmtsLayerName)
+	var wmtsLayerResponse WMTSLayerRequestBody
+	if err = g.requestResource(targetURL, &wmtsLayerResponse); err != nil {
+		return nil, err
+	}
+	return wmtsLayerResponse.WMTSLayer, nil
+}
+
+// PublishWMTSLayer publishes the remote WMTS layer wmtsLayer.NativeName with the name wmtsLayer.Name,
+// if wmtsLayer.NativeName is empty wmtsLayer.Name is used,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) PublishWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayer WMTSLayer) (published bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers")
+	if wmtsLayer.NativeName == "" {
+		wmtsLayer.NativeName = wmtsLayer.Name
+	}
+	return g.createEntity(targetURL, WMTSLayerRequestBody{WMTSLayer: &wmtsLayer}, nil)
+}
+
+// UpdateWMTSLayer partial update the cascaded WMTS layer else return error,
+// fields is an optional field mask, a list of WMTS layer json field names to send even if they are false, 0 or "" (e.g. "enabled"),
+// without fields only the fields set in the wmtsLayer are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string, wmtsLayer WMTSLayer, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers", wmtsLayerName)
+	patch, err := patchEntity(wmtsLayer, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"wmtsLayer": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
+// DeleteWMTSLayer deletes the cascaded WMTS layer, recurse removes the published layer as well,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteWMTSLayer(workspaceName string, wmtsStoreName string, wmtsLayerName string, recurse bool) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName, "layers", wmtsLayerName)
+	return g.deleteEntityWithQuery(targetURL, map[string]string{"recurse": strconv.FormatBool(recurse)})
+}
+
+// SetWMTSLayerTileMatrixSets chooses the tile matrix sets the published WMTS layer is served with,
+// tileMatrixSets are GeoWebCache gridset names (e.g. "EPSG:900913") and should be defined in GeoWebCache (see GetGwcGridsets),
+// they aren't the identifiers of the remote tile matrix sets returned by GetRemoteWMTSTileMatrixSets,
+// a remote tile matrix set can be chosen only through the gridset matching it,
+// the extents of already configured gridsets are kept
+func (g *GeoServer) SetWMTSLayerTileMatrixSets(workspaceName string, wmtsLayerName string, tileMatrixSets []string) (err error) {
+	if len(tileMatrixSets) == 0 {
+		return errors.New("at least one tile matrix set should be chosen")
+	}
+	gridsets, err := g.GetGwcGridsets()
+	if err != nil {
+		return err
+	}
+	defined := make(map[string]bool, len(gridsets))
+	for _, name := range gridsets {
+		defined[name] = true
+	}
+	var undefined []string
+	for _, name := range tileMatrixSets {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	if len(undefined) > 0 {
+		return fmt.Errorf("gridsets %s aren't defined in GeoWebCache", strings.Join(undefined, ", "))
+	}
+	layer, err := g.GetGwcLayer(workspaceName, wmtsLayerName)
+	if err != nil {
+		return err
+	}
+	configured := make(map[string]GwcLayerGridSubset, len(layer.GridSubsets))
+	for _, s := range layer.GridSubsets {
+		configured[s.GridSetName] = s
+	}
+	layer.GridSubsets = make([]GwcLayerGridSubset, 0, len(tileMatrixSets))
+	for _, name := range tileMatrixSets {
+		subset, ok := configured[name]
+		if !ok {
+			subset = GwcLayerGridSubset{GridSetName: name}
+		}
+		layer.GridSubsets = append(layer.GridSubsets, subset)
+	}
+	return g.UpdateGwcLayer(layer)
+}
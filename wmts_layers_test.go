@@ -0,0 +1,104 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWMTSLayers(t *testing.T) {
+	test_before(t)
+	wmtsStoreTestPrecondition(t)
+	defer wmtsStoreTestPostcondition()
+
+	available, err := gsCatalog.GetAvailableWMTSLayers(testWorkspace, wmtsTestStoreName)
+	assert.Nil(t, err)
+	assert.Contains(t, available, "topp:states")
+
+	published, err := gsCatalog.PublishWMTSLayer(testWorkspace, wmtsTestStoreName, WMTSLayer{Name: "cascaded_states", NativeName: "topp:states", Enabled: true})
+	assert.True(t, published)
+	assert.Nil(t, err)
+	layers, err := gsCatalog.GetWMTSLayers(testWorkspace, wmtsTestStoreName)
+	assert.Nil(t, err)
+	assert.Len(t, layers, 1)
+	layer, err := gsCatalog.GetWMTSLayer(testWorkspace, wmtsTestStoreName, "cascaded_states")
+	assert.Nil(t, err)
+	assert.Equal(t, "topp:states", layer.NativeName)
+
+	tileMatrixSets, err := gsCatalog.GetRemoteWMTSTileMatrixSets(testWorkspace, wmtsTestStoreName, "topp:states")
+	assert.Nil(t, err)
+	assert.Contains(t, tileMatrixSets, "EPSG:4326")
+	err = gsCatalog.SetWMTSLayerTileMatrixSets(testWorkspace, "cascaded_states", []string{"EPSG:4326"})
+	assert.Nil(t, err)
+	gwcLayer, err := gsCatalog.GetGwcLayer(testWorkspace, "cascaded_states")
+	assert.Nil(t, err)
+	assert.Len(t, gwcLayer.GridSubsets, 1)
+	err = gsCatalog.SetWMTSLayerTileMatrixSets(testWorkspace, "cascaded_states", nil)
+	assert.NotNil(t, err)
+
+	modified, err := gsCatalog.UpdateWMTSLayer(testWorkspace, wmtsTestStoreName, "cascaded_states", WMTSLayer{Title: "Cascaded states"})
+	assert.True(t, modified)
+	assert.Nil(t, err)
+
+	published, err = gsCatalog.PublishWMTSLayer(testWorkspace, "dummy_store", WMTSLayer{Name: "topp:states"})
+	assert.False(t, published)
+	assert.NotNil(t, err)
+
+	deleted, err := gsCatalog.DeleteWMTSLayer(testWorkspace, wmtsTestStoreName, "cascaded_states", true)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	layer, err = gsCatalog.GetWMTSLayer(testWorkspace, wmtsTestStoreName, "cascaded_states")
+	assert.Nil(t, layer)
+	assert.NotNil(t, err)
+}
+
+func TestSetWMTSLayerTileMatrixSets(t *testing.T) {
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /gwc/rest/gridsets":
+			_, _ = w.Write([]byte(`<gridSets><gridSet><name>EPSG:4326</name></gridSet><gridSet><name>EPSG:900913</name></gridSet></gridSets>`))
+		case "GET /gwc/rest/layers/ws:roads":
+			_, _ = w.Write([]byte(`<GeoServerLayer><name>ws:roads</name><gridSubsets>
+				<gridSubset><gridSetName>EPSG:4326</gridSetName><extent><coords><double>0</double><double>0</double><double>1</double><double>1</double></coords></extent></gridSubset>
+			</gridSubsets></GeoServerLayer>`))
+		case "PUT /gwc/rest/layers/ws:roads":
+			body, _ := ioutil.ReadAll(r.Body)
+			updated = string(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	gsCatalog := GetCatalog(server.URL+"/", "admin", "geoserver")
+
+	gridsets, err := gsCatalog.GetGwcGridsets()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"EPSG:4326", "EPSG:900913"}, gridsets)
+
+	err = gsCatalog.SetWMTSLayerTileMatrixSets("ws", "roads", []string{"EPSG:4326", "GoogleMapsCompatible"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GoogleMapsCompatible")
+	assert.Empty(t, updated)
+
+	err = gsCatalog.SetWMTSLayerTileMatrixSets("ws", "roads", []string{"EPSG:900913", "EPSG:4326"})
+	assert.Nil(t, err)
+	var layer GwcLayer
+	assert.Nil(t, xml.Unmarshal([]byte(updated), &layer))
+	assert.Len(t, layer.GridSubsets, 2)
+	assert.Equal(t, "EPSG:900913", layer.GridSubsets[0].GridSetName)
+	assert.Nil(t, layer.GridSubsets[0].Extent)
+	assert.Equal(t, []float64{0, 0, 1, 1}, layer.GridSubsets[1].Extent.Coords)
+}
+
+func TestGeoserverImplementWMTSLayerService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	WMTSLayerServiceType := reflect.TypeOf((*WMTSLayerService)(nil)).Elem()
+	check := gsCatalog.Implements(WMTSLayerServiceType)
+	assert.True(t, check)
+}
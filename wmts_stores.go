@@ -0,0 +1,153 @@
+package geoserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// WMTSStoreService define all geoserver WMTS stores (cascaded WMTS servers) operations
+type WMTSStoreService interface {
+	GetWMTSStores(workspaceName string) (wmtsStores []*Resource, err error)
+	GetWMTSStore(workspaceName string, wmtsStoreName string) (wmtsStore *WMTSStore, err error)
+	CreateWMTSStore(workspaceName string, wmtsStore WMTSStore) (created bool, err error)
+	UpdateWMTSStore(workspaceName string, wmtsStoreName string, wmtsStore WMTSStore, fields ...string) (modified bool, err error)
+	DeleteWMTSStore(workspaceName string, wmtsStoreName string, recurse bool) (deleted bool, err error)
+	GetRemoteWMTSTileMatrixSets(workspaceName string, wmtsStoreName string, nativeLayerName string) (tileMatrixSets []string, err error)
+}
+
+// WMTSStore geoserver WMTS store, the remote WMTS server cascaded by geoserver,
+// ReadTimeout and ConnectTimeout are in seconds,
+// HeaderName and HeaderValue define an additional http header sent to the remote server
+type WMTSStore struct {
+	Name                 string    `json:"name,omitempty"`
+	Description          string    `json:"description,omitempty"`
+	Type                 string    `json:"type,omitempty"`
+	Enabled              bool      `json:"enabled,omitempty"`
+	Workspace            *Resource `json:"workspace,omitempty"`
+	Default              bool      `json:"_default,omitempty"`
+	CapabilitiesURL      string    `json:"capabilitiesURL,omitempty"`
+	User                 string    `json:"user,omitempty"`
+	Password             string    `json:"password,omitempty"`
+	MaxConnections       int       `json:"maxConnections,omitempty"`
+	ReadTimeout          int       `json:"readTimeout,omitempty"`
+	ConnectTimeout       int       `json:"connectTimeout,omitempty"`
+	UseConnectionPooling *bool     `json:"useConnectionPooling,omitempty"`
+	HeaderName           string    `json:"headerName,omitempty"`
+	HeaderValue          string    `json:"headerValue,omitempty"`
+	Metadata             *Metadata `json:"metadata,omitempty"`
+	WMTSLayers           string    `json:"layers,omitempty"`
+}
+
+// WMTSStoreRequestBody geoserver WMTS store to send to api
+type WMTSStoreRequestBody struct {
+	WMTSStore *WMTSStore `json:"wmtsStore,omitempty"`
+}
+
+// wmtsCapabilities is the part of the WMTS capabilities document describing the layers tile matrix sets
+type wmtsCapabilities struct {
+	Layers []struct {
+		Identifier     string   `xml:"Identifier"`
+		TileMatrixSets []string `xml:"TileMatrixSetLink>TileMatrixSet"`
+	} `xml:"Contents>Layer"`
+}
+
+// GetWMTSStores return all WMTS stores in the workspace as resources,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMTSStores(workspaceName string) (wmtsStores []*Resource, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores")
+	return g.requestResourceList(targetURL, nil, "wmtsStores", "wmtsStore")
+}
+
+// GetWMTSStore return WMTS store from the workspace,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetWMTSStore(workspaceName string, wmtsStoreName string) (wmtsStore *WMTSStore, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName)
+	var wmtsStoreResponse WMTSStoreRequestBody
+	if err = g.requestResource(targetURL, &wmtsStoreResponse); err != nil {
+		return nil, err
+	}
+	return wmtsStoreResponse.WMTSStore, nil
+}
+
+// CreateWMTSStore creates WMTS store in the workspace, if wmtsStore.Type is empty "WMTS" is used,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) CreateWMTSStore(workspaceName string, wmtsStore WMTSStore) (created bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores")
+	if wmtsStore.Type == "" {
+		wmtsStore.Type = "WMTS"
+	}
+	return g.createEntity(targetURL, WMTSStoreRequestBody{WMTSStore: &wmtsStore}, nil)
+}
+
+// UpdateWMTSStore partial update WMTS store in geoserver else return error,
+// fields is an optional field mask, a list of WMTS store json field names to send even if they are false, 0 or "" (e.g. "enabled"),
+// without fields only the fields set in the wmtsStore are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWMTSStore(workspaceName string, wmtsStoreName string, wmtsStore WMTSStore, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName)
+	patch, err := patchEntity(wmtsStore, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"wmtsStore": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Error(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
+// DeleteWMTSStore deletes WMTS store from geoserver, recurse removes the cascaded layers as well,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) DeleteWMTSStore(workspaceName string, wmtsStoreName string, recurse bool) (deleted bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName, "wmtsstores", wmtsStoreName)
+	return g.deleteEntityWithQuery(targetURL, map[string]string{"recurse": strconv.FormatBool(recurse)})
+}
+
+// GetRemoteWMTSTileMatrixSets reads the capabilities document of the remote WMTS server of the store
+// and returns the identifiers of the tile matrix sets available for the remote layer nativeLayerName,
+// geoserver rest api doesn't expose them, so the document is requested by the client directly from store.CapabilitiesURL,
+// the remote server should be reachable from the client and allow the anonymous capabilities requests,
+// the store credentials aren't sent (geoserver returns the password encrypted), the store http header is sent,
+// err is an error if error occurred else err is nil
+func (g *GeoServer) GetRemoteWMTSTileMatrixSets(workspaceName string, wmtsStoreName string, nativeLayerName string) (tileMatrixSets []string, err error) {
+	store, err := g.GetWMTSStore(workspaceName, wmtsStoreName)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(getMethod, store.CapabilitiesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if store.HeaderName != "" {
+		request.Header.Set(store.HeaderName, store.HeaderValue)
+	}
+	response, err := g.HttpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != statusOk {
+		return nil, g.GetError(response.StatusCode, body)
+	}
+
+	var capabilities wmtsCapabilities
+	if err = xml.Unmarshal(body, &capabilities); err != nil {
+		return nil, fmt.Errorf("can't parse WMTS capabilities from %v: %v", store.CapabilitiesURL, err)
+	}
+	for _, layer := range capabilities.Layers {
+		if layer.Identifier == nativeLayerName {
+			return layer.TileMatrixSets, nil
+		}
+	}
+	return nil, fmt.Errorf("layer %s isn't found in WMTS capabilities from %v", nativeLayerName, store.CapabilitiesURL)
+}
@@ -0,0 +1,118 @@
+package geoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	wmtsTestStoreName       = "cascaded_wmts_test"
+	wmtsTestCapabilitiesURL = "http://localhost:8080/geoserver/gwc/service/wmts?REQUEST=GetCapabilities"
+)
+
+func wmtsStoreTestPrecondition(t *testing.T) {
+	_, err := gsCatalog.CreateWorkspace(testWorkspace)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		assert.Fail(t, "can't create workspace as a precondition for WMTS store test")
+	}
+	_, err = gsCatalog.CreateWMTSStore(testWorkspace, WMTSStore{
+		Name:            wmtsTestStoreName,
+		CapabilitiesURL: wmtsTestCapabilitiesURL,
+		Enabled:         true,
+		MaxConnections:  6,
+		ReadTimeout:     60,
+		ConnectTimeout:  30,
+	})
+	if err != nil && !strings.Contains(err.Error(), "exists") {
+		assert.Fail(t, "can't create WMTS store as a precondition for WMTS store test", err.Error())
+	}
+}
+
+func wmtsStoreTestPostcondition() {
+	_, _ = gsCatalog.DeleteWorkspace(testWorkspace, true)
+}
+
+func TestWMTSStores(t *testing.T) {
+	test_before(t)
+	wmtsStoreTestPrecondition(t)
+	defer wmtsStoreTestPostcondition()
+
+	stores, err := gsCatalog.GetWMTSStores(testWorkspace)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, stores)
+	store, err := gsCatalog.GetWMTSStore(testWorkspace, wmtsTestStoreName)
+	assert.Nil(t, err)
+	assert.Equal(t, wmtsTestCapabilitiesURL, store.CapabilitiesURL)
+	assert.Equal(t, "WMTS", store.Type)
+
+	modified, err := gsCatalog.UpdateWMTSStore(testWorkspace, wmtsTestStoreName, WMTSStore{Enabled: false, ReadTimeout: 10}, "enabled", "readTimeout")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	store, err = gsCatalog.GetWMTSStore(testWorkspace, wmtsTestStoreName)
+	assert.Nil(t, err)
+	assert.False(t, store.Enabled)
+	assert.Equal(t, 10, store.ReadTimeout)
+
+	created, err := gsCatalog.CreateWMTSStore(testWorkspace, WMTSStore{Name: wmtsTestStoreName, CapabilitiesURL: wmtsTestCapabilitiesURL})
+	assert.False(t, created)
+	assert.NotNil(t, err)
+	store, err = gsCatalog.GetWMTSStore(testWorkspace, "dummy_store")
+	assert.Nil(t, store)
+	assert.NotNil(t, err)
+
+	deleted, err := gsCatalog.DeleteWMTSStore(testWorkspace, wmtsTestStoreName, true)
+	assert.True(t, deleted)
+	assert.Nil(t, err)
+	deleted, err = gsCatalog.DeleteWMTSStore(testWorkspace, wmtsTestStoreName, true)
+	assert.False(t, deleted)
+	assert.NotNil(t, err)
+}
+
+func TestGetRemoteWMTSTileMatrixSets(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/workspaces/ws/wmtsstores/remote":
+			_, _ = w.Write([]byte(`{"wmtsStore":{"name":"remote","type":"WMTS","capabilitiesURL":"` + serverURL + `/wmts","user":"remote","password":"crypt1:K9GuCQ+DfP0=","headerName":"X-Key","headerValue":"secret"}}`))
+		case "/wmts":
+			_, _, auth := r.BasicAuth()
+			assert.False(t, auth)
+			if r.Header.Get("X-Key") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`<Capabilities><Contents>
+				<Layer><Identifier>roads</Identifier>
+					<TileMatrixSetLink><TileMatrixSet>EPSG:4326</TileMatrixSet></TileMatrixSetLink>
+					<TileMatrixSetLink><TileMatrixSet>EPSG:900913</TileMatrixSet></TileMatrixSetLink>
+				</Layer>
+			</Contents></Capabilities>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	gsCatalog := GetCatalog(server.URL, "admin", "geoserver")
+
+	tileMatrixSets, err := gsCatalog.GetRemoteWMTSTileMatrixSets("ws", "remote", "roads")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"EPSG:4326", "EPSG:900913"}, tileMatrixSets)
+	tileMatrixSets, err = gsCatalog.GetRemoteWMTSTileMatrixSets("ws", "remote", "rivers")
+	assert.Nil(t, tileMatrixSets)
+	assert.NotNil(t, err)
+	_, err = gsCatalog.GetRemoteWMTSTileMatrixSets("ws", "dummy", "roads")
+	assert.NotNil(t, err)
+}
+
+func TestGeoserverImplementWMTSStoreService(t *testing.T) {
+	gsCatalog := reflect.TypeOf(&GeoServer{})
+	WMTSStoreServiceType := reflect.TypeOf((*WMTSStoreService)(nil)).Elem()
+	check := gsCatalog.Implements(WMTSStoreServiceType)
+	assert.True(t, check)
+}
@@ -20,6 +20,9 @@ type WorkspaceService interface {
 	// CreateWorkspace creates a workspace else return error
 	CreateWorkspace(workspaceName string) (created bool, err error)
 
+	// UpdateWorkspace partial update geoserver workspace else return error, fields is an optional field mask
+	UpdateWorkspace(workspaceName string, workspace Workspace, fields ...string) (modified bool, err error)
+
 	//DeleteWorkspace delete geoserver workspace and its reources else return error
 	DeleteWorkspace(workspaceName string, recurse bool) (deleted bool, err error)
 }
@@ -65,6 +68,26 @@ func (g *GeoServer) CreateWorkspace(workspaceName string) (created bool, err err
 	return
 }
 
+// UpdateWorkspace partial update geoserver workspace else return error,
+// fields is an optional field mask, a list of workspace json field names to send even if they are false or "" (e.g. "isolated"),
+// without fields only the fields set in the workspace are sent, the others are left untouched on the server
+func (g *GeoServer) UpdateWorkspace(workspaceName string, workspace Workspace, fields ...string) (modified bool, err error) {
+	targetURL := g.ParseURL("rest", "workspaces", workspaceName)
+	patch, err := patchEntity(workspace, fields)
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{"workspace": patch}
+
+	return g.updateEntity(targetURL, data, func(statusCode int, response []byte) error {
+		if statusCode != statusOk {
+			g.logger.Warn(string(response))
+			return g.GetError(statusCode, response)
+		}
+		return nil
+	})
+}
+
 // WorkspaceExists check if workspace in geoserver or not else return error
 func (g *GeoServer) WorkspaceExists(workspaceName string) (exists bool, err error) {
 	_, workspaceErr := g.GetWorkspace(workspaceName)
@@ -27,6 +27,18 @@ func TestWorkspaceExists(t *testing.T) {
 	assert.False(t, exists)
 	assert.NotNil(t, err)
 }
+func TestUpdateWorkspace(t *testing.T) {
+	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
+	modified, err := gsCatalog.UpdateWorkspace("golang_workspace_test", Workspace{Isolated: false}, "isolated")
+	assert.True(t, modified)
+	assert.Nil(t, err)
+	modified, err = gsCatalog.UpdateWorkspace("golang_workspace_test", Workspace{}, "dummy_field")
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+	modified, err = gsCatalog.UpdateWorkspace("golang_workspace_test_dummy", Workspace{Isolated: true})
+	assert.False(t, modified)
+	assert.NotNil(t, err)
+}
 func TestGetWorkspace(t *testing.T) {
 	gsCatalog := GetCatalog("http://localhost:8080/geoserver/", "admin", "geoserver")
 	workspace, err := gsCatalog.GetWorkspace("cite")